@@ -0,0 +1,43 @@
+package cli
+
+import "github.com/Dannflower/godle/logic"
+
+// Start a game against an opponent that dodges every guess for as long as it can.
+func (s *Session) playAbsurdle() error {
+
+	game := logic.NewAbsurdleGame()
+	record := s.startRecord("absurdle", 0)
+
+	s.say("There is no word yet. Corner it!")
+
+	for !game.HasWon() {
+
+		guess, err := s.promptLine("Guess: ")
+
+		if err != nil {
+			return err
+		}
+
+		err = game.MakeGuess(guess)
+
+		if err != nil {
+
+			s.say("Invalid guess: %v.", err)
+
+		} else {
+
+			s.printResults(game.Guesses, game.Results)
+			s.printAvailableLetters(game.UsedLetters)
+			s.printKnowledge(game.Guesses, game.Results)
+			s.say("Words still possible: %v", len(game.Candidates))
+		}
+	}
+
+	s.recordHistory(record, true, game.Answer(), game.Guesses, game.Results)
+
+	return s.handleGameOver(Outcome{
+		Won:      true,
+		Guesses:  len(game.Guesses),
+		Headline: "You cornered it!",
+	})
+}
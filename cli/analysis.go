@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Shows how each guess of the finished game compares with the best
+// guess that could have been made. Games whose answer isn't one of
+// the answer words, like most hot-seat games, can't be analyzed.
+func (s *Session) printAnalysis(game *logic.Game) {
+
+	analysis, err := logic.Analyze(game.Guesses, game.Results)
+
+	// A game given up before any guess has nothing to analyse
+	if err != nil || len(analysis) == 0 {
+		return
+	}
+
+	var table strings.Builder
+	w := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Guess\tLeft\tBits\tExpected\tBest\t")
+
+	for _, a := range analysis {
+
+		luck := ""
+
+		if a.Lucky {
+			luck = "lucky!"
+		}
+
+		fmt.Fprintf(w, "%s\t%v -> %v\t%.2f\t%.2f\t%s %.2f\t%s\n",
+			strings.ToUpper(a.Guess), a.Before, a.After, a.Bits, a.ExpectedBits,
+			strings.ToUpper(a.BestGuess), a.BestBits, luck)
+	}
+
+	w.Flush()
+
+	s.say("")
+
+	for _, line := range strings.Split(strings.TrimRight(table.String(), "\n"), "\n") {
+		s.say("%s", strings.TrimRight(line, " "))
+	}
+
+	s.say("")
+}
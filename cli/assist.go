@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// The most remaining answers listed by the assistant.
+const assistListLimit = 20
+
+// The number of next guesses suggested by the assistant.
+const assistSuggestions = 5
+
+// Helps solve a puzzle played in another client. Each line is a guess
+// and the colors it was given, such as "crane bygbb", after which the
+// answers still possible and the best next guesses are shown. Runs
+// until the player quits or the input ends.
+func (s *Session) Assist() error {
+
+	s.say("Enter each guess and its colors, e.g. crane bygbb (g green, y yellow, b gray).")
+	s.say("Type /undo to take back the last guess, /reset to start over or /quit to leave.")
+
+	var guesses []string
+	var results [][]int
+
+	for {
+
+		line, err := s.promptLine("Guess and colors: ")
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		switch strings.ToLower(line) {
+
+		case "":
+			continue
+
+		case "/quit":
+			return nil
+
+		case "/reset":
+			guesses, results = nil, nil
+			s.say("Starting over.")
+			continue
+
+		case "/undo":
+			if len(guesses) == 0 {
+
+				s.say("There is nothing to undo.")
+				continue
+			}
+
+			guesses, results = guesses[:len(guesses)-1], results[:len(results)-1]
+
+		default:
+			guess, result, err := parseAssistEntry(line)
+
+			if err != nil {
+
+				s.say("Invalid entry: %v.", err)
+				continue
+			}
+
+			guesses = append(guesses, guess)
+			results = append(results, result)
+		}
+
+		s.printResults(guesses, results)
+		s.printKnowledge(guesses, results)
+		s.printSuggestions(guesses, results)
+	}
+}
+
+// Parses a line such as "crane bygbb" into the guess and its result.
+func parseAssistEntry(line string) (string, []int, error) {
+
+	fields := strings.Fields(line)
+
+	if len(fields) != 2 {
+		return "", nil, errors.New("expected a guess and its colors")
+	}
+
+	guess := strings.ToLower(fields[0])
+
+	if err := logic.ValidateWord(guess); err != nil {
+		return "", nil, err
+	}
+
+	result, err := logic.ParsePattern(fields[1])
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(result) != len(guess) {
+		return "", nil, errors.New("expected one color for every letter")
+	}
+
+	return guess, result, nil
+}
+
+// Shows the answers that fit every result and the guesses
+// expected to narrow them down the most.
+func (s *Session) printSuggestions(guesses []string, results [][]int) {
+
+	candidates, err := logic.Candidates(guesses, results)
+
+	if err != nil || len(candidates) == 0 {
+
+		s.say("No answer word fits those colors. Check them, or /undo the last guess.")
+		return
+	}
+
+	if len(candidates) == 1 {
+
+		s.say("The answer is %s.", strings.ToUpper(candidates[0]))
+		return
+	}
+
+	s.say("Answers remaining: %v", len(candidates))
+
+	if len(candidates) <= assistListLimit {
+		s.say("%s", strings.ToUpper(strings.Join(candidates, " ")))
+	}
+
+	s.say("Best next guesses:")
+
+	for _, ranked := range logic.RankGuesses(candidates, assistSuggestions) {
+
+		answer := ""
+
+		for _, candidate := range candidates {
+
+			if candidate == ranked.Word {
+				answer = " (could be the answer)"
+			}
+		}
+
+		s.say("  %s %.2f bits%s", strings.ToUpper(ranked.Word), ranked.Bits, answer)
+	}
+
+	if s.Tree != nil {
+
+		if guess, ok := s.Tree.Next(guesses, results); ok {
+			s.say("Decision tree: play %s", strings.ToUpper(guess))
+		} else {
+			s.say("Decision tree: these guesses left the tree.")
+		}
+	}
+
+	if s.Strategy != nil {
+
+		state := logic.SolverState{Guesses: guesses, Results: results, Candidates: candidates}
+		s.say("Strategy pick: %s", strings.ToUpper(s.Strategy.NextGuess(state)))
+	}
+}
@@ -0,0 +1,431 @@
+// Package cli is the interactive command line game. A session reads
+// commands and guesses from any reader and writes to any writer, so
+// it can be played on a local terminal, over the network or by a script.
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// The terminal width assumed when the real one isn't known.
+const defaultWidth = 80
+
+// An interactive game played by reading lines from one stream and writing
+// to another. Every session has its own games, so any number can run at once.
+type Session struct {
+	// Where the player's stats are kept. Stats aren't
+	// recorded if it is empty.
+	StatsPath string
+	// Where the record of every finished game is appended.
+	// History isn't recorded if it is empty.
+	HistoryPath string
+	// Whether the output is a terminal the live timer can be drawn on.
+	Terminal bool
+	// Chooses the answer for each new game. If it is nil, each answer
+	// is chosen from a random seed that is kept in the history.
+	SelectAnswer func() string
+	// The clock timed games are played against.
+	Clock logic.Clock
+	// Where the events of every classic game played in the session
+	// are emitted. It may be shared with other sessions.
+	Events *logic.Events
+	// Chooses the word suggested by /hint word. If it is nil, the
+	// first answer that fits every result so far is suggested.
+	Strategy logic.Strategy
+	// The decision tree the assistant looks the next guess up in, if any.
+	Tree *logic.DecisionTree
+	// Reads a line without echoing it, for the secret words of hot-seat
+	// games. If it is nil, hidden prompts rely on the renderer to keep
+	// what is typed out of sight, and hot-seat is refused if it can't.
+	ReadHidden func() (string, error)
+
+	in *bufio.Scanner
+	// A read left running when its deadline passed, which the next
+	// read takes its line from.
+	pending chan readResult
+	out     io.Writer
+	// Whether the renderer's hidden prompts conceal what is typed.
+	conceals bool
+	// Held for every write to out, so the live timer
+	// never draws in the middle of other output.
+	outMu    sync.Mutex
+	renderer Renderer
+	// The width of the terminal in columns, which
+	// may change while the session is running.
+	width atomic.Int32
+}
+
+// Creates a session reading from in and writing to out, showing hints with the renderer.
+func New(in io.Reader, out io.Writer, renderer Renderer) *Session {
+
+	s := &Session{
+		Clock:  logic.SystemClock{},
+		Events: logic.NewEvents(),
+		in:     bufio.NewScanner(in),
+		out:    out,
+	}
+	s.renderer = lockedRenderer{Renderer: renderer, mu: &s.outMu}
+	_, s.conceals = renderer.(inputConcealer)
+	s.width.Store(defaultWidth)
+
+	return s
+}
+
+// Sets the width of the terminal in columns. It is safe to
+// call while the session is running.
+func (s *Session) SetWidth(columns int) {
+
+	if columns > 0 {
+		s.width.Store(int32(columns))
+	}
+}
+
+// Runs the session from the title screen until the player quits or
+// the input ends. An error is returned only if reading the input failed.
+func (s *Session) Run() error {
+
+	s.printTitle()
+	s.printMenu()
+
+	err := s.handleMenuInput()
+
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+// Returned when a deadline passes before a line of input is read.
+var errNoInput = errors.New("no input before the deadline")
+
+// Reads the next line of input. At the end of the input io.EOF
+// is returned, and if reading fails that error is returned.
+func (s *Session) readLine() (string, error) {
+
+	return s.readLineUntil(nil)
+}
+
+// A line of input and the error reading it.
+type readResult struct {
+	line string
+	err  error
+}
+
+// Reads the next line of input as readLine does, unless the deadline
+// receives first, in which case errNoInput is returned and the line is
+// kept for the next read. A nil deadline never passes.
+func (s *Session) readLineUntil(deadline <-chan time.Time) (string, error) {
+
+	if s.pending == nil {
+
+		// Without a deadline there's nothing to wait on but the input
+		if deadline == nil {
+			return s.scanLine()
+		}
+
+		pending := make(chan readResult, 1)
+		go func() {
+			line, err := s.scanLine()
+			pending <- readResult{line, err}
+		}()
+		s.pending = pending
+	}
+
+	select {
+
+	case result := <-s.pending:
+		s.pending = nil
+		return result.line, result.err
+
+	case <-deadline:
+		return "", errNoInput
+	}
+}
+
+// Scans the next line of input.
+func (s *Session) scanLine() (string, error) {
+
+	if s.in.Scan() {
+		return s.in.Text(), nil
+	}
+
+	if err := s.in.Err(); err != nil {
+		return "", err
+	}
+
+	return "", io.EOF
+}
+
+// Prints the prompt and returns the trimmed line typed in response.
+func (s *Session) promptLine(prompt string) (string, error) {
+
+	return s.promptLineUntil(prompt, nil)
+}
+
+// Prints the prompt and returns the trimmed line typed in response,
+// or errNoInput if the deadline receives first.
+func (s *Session) promptLineUntil(prompt string, deadline <-chan time.Time) (string, error) {
+
+	s.renderer.Prompt(s.out, prompt, false)
+	line, err := s.readLineUntil(deadline)
+
+	return strings.TrimSpace(line), err
+}
+
+// Returns true if what is typed at hidden prompts is kept out of sight.
+func (s *Session) canHideInput() bool {
+
+	return s.ReadHidden != nil || s.conceals
+}
+
+// Prints the prompt and returns the trimmed line typed in response,
+// keeping what is typed out of sight of anyone else watching.
+func (s *Session) promptHidden(prompt string) (string, error) {
+
+	if s.ReadHidden != nil {
+
+		s.renderer.Prompt(s.out, prompt, false)
+		line, err := s.ReadHidden()
+
+		return strings.TrimSpace(line), err
+	}
+
+	s.renderer.Prompt(s.out, prompt, true)
+	line, err := s.readLine()
+	s.renderer.Clear(s.out)
+
+	return strings.TrimSpace(line), err
+}
+
+// Prints the prompt to return to the menu and waits for the player.
+func (s *Session) waitForEnter() error {
+
+	s.say("Hit enter to return to the menu.")
+	_, err := s.readLine()
+
+	return err
+}
+
+func (s *Session) printTitle() {
+
+	title := "  _____           _ _       \n" +
+		" / ____|         | | |      \n" +
+		"| |  __  ___   __| | | ___  \n" +
+		"| | |_ |/ _ \\ / _` | |/ _ \\ \n" +
+		"| |__| | (_) | (_| | |  __/ \n" +
+		" \\_____|\\___/ \\__,_|_|\\___| \n"
+
+	s.say("%s", title)
+}
+
+func (s *Session) printMenu() {
+
+	s.say("Options\t\tKey")
+	s.say("-------\t\t---")
+	s.say("Play\t\t p")
+	s.say("Multi-board\t m")
+	s.say("Absurdle\t a")
+	s.say("Fibble\t\t f")
+	s.say("Jotto\t\t j")
+	s.say("Hot-seat\t h")
+	s.say("Marathon\t k")
+	s.say("Speedrun\t s")
+	s.say("Countdown\t c")
+	s.say("Stats\t\t t")
+	s.say("Rules\t\t r")
+	s.say("Quit\t\t q")
+	s.say("")
+}
+
+// Handles menu option selections until the player quits, returning
+// nil, or the input ends or fails, returning the read error.
+func (s *Session) handleMenuInput() error {
+
+	// Each option runs until it is finished, then the menu is shown again
+	options := map[string]func() error{
+		"p": s.play,
+		"m": s.playMulti,
+		"a": s.playAbsurdle,
+		"f": s.playFibble,
+		"j": s.playJotto,
+		"h": s.playHotSeat,
+		"k": s.playMarathon,
+		"s": s.playSpeedrun,
+		"c": s.playCountdown,
+		"t": s.printStats,
+		"r": s.printRules,
+	}
+
+	for {
+
+		command, err := s.promptLine("Command: ")
+
+		if err != nil {
+			return err
+		}
+
+		if command == "q" {
+
+			s.say("Thanks for playing!")
+			return nil
+		}
+
+		option, ok := options[command]
+
+		if !ok {
+
+			s.say("Invalid option.")
+			// loop back to start of input
+			continue
+		}
+
+		if err := option(); err != nil {
+			return err
+		}
+
+		s.printMenu()
+	}
+}
+
+func (s *Session) printRules() error {
+
+	s.say("Attempt to guess a randomly selected 5-letter word.")
+	s.say("You get %v guesses to get the right word.", logic.MaxGuesses)
+	s.say("After guessing your guess will be displayed with color coding indicating the following:")
+	s.say("Gray (%s) - The letter is not in the word.", s.renderer.Hint("A", logic.NotInWord))
+	s.say("Yellow (%s) - The letter is in the word but is in the wrong position.", s.renderer.Hint("A", logic.WrongPosition))
+	s.say("Green (%s) - The letter is in the word and in the right position.", s.renderer.Hint("A", logic.CorrectPosition))
+	s.say("If all guesses are exhausted, the answer will be revealed. Good luck word nerd!")
+
+	return s.waitForEnter()
+}
+
+// Shows the outcome of a finished game and waits for the player.
+func (s *Session) handleGameOver(outcome Outcome) error {
+
+	s.renderer.GameOver(s.out, outcome)
+
+	return s.waitForEnter()
+}
+
+// Start the core game loop.
+func (s *Session) play() error {
+
+	game, seed := s.newGame()
+	record := s.startRecord("classic", seed)
+	won, err := s.playRound(game)
+
+	if errors.Is(err, errQuitGame) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.recordGame(won, len(game.Guesses), game.Hints, 0)
+	record.Hints = game.Hints
+	s.recordHistory(record, won, game.Answer, game.Guesses, game.Results)
+
+	return s.waitForEnter()
+}
+
+// Plays the game until the word is guessed, the guesses run out or the
+// player gives up, then shows the outcome. Returns true if the word was
+// guessed, or errQuitGame if the player left the game. The caller waits
+// for the player once the game is recorded.
+func (s *Session) playRound(game *logic.Game) (bool, error) {
+
+	s.say("Guess the word! Type /help for commands.")
+
+	for !game.IsOver() {
+
+		guess, err := s.promptLine("Guess: ")
+
+		if err != nil {
+			return false, err
+		}
+
+		if command, err := s.runGameCommand(game, guess); command {
+
+			if err != nil {
+				return false, err
+			}
+
+			continue
+		}
+
+		err = game.MakeGuess(guess)
+
+		if err != nil {
+
+			s.say("Invalid guess: %v.", err)
+
+		} else {
+
+			s.printResults(game.Guesses, game.Results)
+			s.printAvailableLetters(game.UsedLetters)
+			s.printKnowledge(game.Guesses, game.Results)
+		}
+	}
+
+	outcome := Outcome{
+		Won:        game.HasWon(),
+		Guesses:    len(game.Guesses),
+		MaxGuesses: logic.MaxGuesses,
+	}
+
+	if !outcome.Won {
+		outcome.Answers = []string{game.Answer}
+	}
+
+	s.renderer.GameOver(s.out, outcome)
+	s.say("")
+	s.say("%s", logic.Share{
+		Title:      "Godle",
+		Results:    game.Results,
+		Won:        outcome.Won,
+		MaxGuesses: logic.MaxGuesses,
+		Hints:      game.Hints,
+	})
+	s.printAnalysis(game)
+
+	return outcome.Won, nil
+}
+
+// Shows each of the guesses with its matching result.
+func (s *Session) printResults(guesses []string, results [][]int) {
+
+	s.renderer.Board(s.out, Board{Guesses: guesses, Results: results})
+}
+
+// Shows the complete list of letters with any used in
+// previous guesses marked up by their hint.
+func (s *Session) printAvailableLetters(usedLetters map[rune]int) {
+
+	s.renderer.Keyboard(s.out, usedLetters)
+}
+
+// Shows a summary of what the results have revealed about the answer.
+func (s *Session) printKnowledge(guesses []string, results [][]int) {
+
+	if k, err := logic.BuildKnowledge(guesses, results); err == nil {
+		s.renderer.Knowledge(s.out, k)
+	}
+}
+
+// Shows a line of text to the player.
+func (s *Session) say(format string, args ...interface{}) {
+
+	s.renderer.Message(s.out, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,578 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dannflower/godle/history"
+	"github.com/Dannflower/godle/logic"
+	"github.com/Dannflower/godle/stats"
+)
+
+// A clock that never moves.
+type fixedClock struct{}
+
+func (fixedClock) Now() time.Time {
+
+	return time.Unix(0, 0)
+}
+
+// Timers never fire, since the clock doesn't move on its own.
+func (fixedClock) After(d time.Duration) <-chan time.Time {
+
+	return nil
+}
+
+// A reader that fails after its input is used up.
+type failingReader struct {
+	io.Reader
+	err error
+}
+
+func (f failingReader) Read(p []byte) (int, error) {
+
+	n, err := f.Reader.Read(p)
+
+	if err == io.EOF {
+		err = f.err
+	}
+
+	return n, err
+}
+
+// Runs a session on the scripted input with a fixed answer,
+// returning everything written and the error from Run.
+func runScript(t *testing.T, input string, answer string) (string, error) {
+
+	return runSession(t, New(strings.NewReader(input), nil, PlainRenderer{}), input, answer)
+}
+
+// Runs the session with a fixed answer as runScript does, for sessions
+// that need more set up than runScript gives them.
+func runSession(t *testing.T, s *Session, input string, answer string) (string, error) {
+
+	var out bytes.Buffer
+
+	s.out = &out
+	s.SelectAnswer = func() string { return answer }
+	s.Clock = fixedClock{}
+	s.StatsPath = filepath.Join(t.TempDir(), "stats.json")
+
+	done := make(chan error)
+	go func() { done <- s.Run() }()
+
+	select {
+	case err := <-done:
+		return out.String(), err
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run() did not return for input %q.", input)
+		return "", nil
+	}
+}
+
+// Fails the test unless output contains every one of the expected strings.
+func expectOutput(t *testing.T, output string, expected ...string) {
+
+	for _, e := range expected {
+
+		if !strings.Contains(output, e) {
+			t.Fatalf("Output did not contain %q. Output:\n%s", e, output)
+		}
+	}
+}
+
+func TestQuit(t *testing.T) {
+
+	output, err := runScript(t, "x\nq\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Invalid option.", "Thanks for playing!")
+}
+
+func TestRules(t *testing.T) {
+
+	output, err := runScript(t, "r\n\nq\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	// Each colour is named in plain text with an example of how it is shown
+	expectOutput(t, output, "Gray (.) - The letter is not in the word.", "Yellow (a) - ", "Green (A) - ")
+}
+
+func TestEndOfInput(t *testing.T) {
+
+	// At the menu, mid-game and at every other prompt
+	scripts := []string{"", "p\ncrane\n", "p\npiety\n", "r\n", "m\n", "m\n2\n", "h\na\nb\n", "c\ng\n", "s\n", "t\n", "a\n", "f\n", "j\n", "k\n"}
+
+	for _, script := range scripts {
+
+		output, err := runScript(t, script, "piety")
+
+		if err != nil {
+			t.Fatalf("Run() returned an error for input %q: %v", script, err)
+		}
+
+		if strings.Contains(output, "Thanks for playing!") {
+			t.Fatalf("Run() quit normally at the end of input %q.", script)
+		}
+	}
+}
+
+func TestReadError(t *testing.T) {
+
+	readErr := errors.New("connection lost")
+	s := New(failingReader{strings.NewReader("p\n"), readErr}, io.Discard, PlainRenderer{})
+
+	if err := s.Run(); !errors.Is(err, readErr) {
+		t.Fatalf("Run() returned %v, expected %v.", err, readErr)
+	}
+}
+
+func TestPlayWin(t *testing.T) {
+
+	output, err := runScript(t, "p\naaaaa\ncrane\npiety\n\nq\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output,
+		"Invalid guess: must be a valid word.",
+		"CRANE bbbby\n",
+		"PIETY ggggg\n",
+		"You got it!",
+		"Guesses: 2/6",
+		"Thanks for playing!")
+}
+
+func TestPlayLoss(t *testing.T) {
+
+	output, err := runScript(t, "p\ncrane\nslate\nfjord\nabout\nhello\nworld\n\nq\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Nice try! The word was 'piety.'", "Thanks for playing!")
+}
+
+func TestGameCommands(t *testing.T) {
+
+	output, err := runScript(t, "p\n/help\ncrane\n/hint\n/remaining\n/board\n/bogus\n/giveup\n\nq\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "/giveup", "Letter 1 is P.", "Answers remaining: ", "Unknown command /bogus.",
+		"Nice try! The word was 'piety.'", "Godle X/6 💡1\n")
+}
+
+func TestHintTwice(t *testing.T) {
+
+	output, err := runScript(t, "p\ncrane\n/hint\n/hint\n/giveup\n\nq\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Letter 1 is P.", "Letter 2 is I.", "Godle X/6 💡2\n")
+}
+
+func TestGameQuit(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "stats.json")
+	s := New(strings.NewReader("p\ncrane\n/quit\nq\n"), io.Discard, PlainRenderer{})
+	s.SelectAnswer = func() string { return "piety" }
+	s.StatsPath = path
+
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	if st, _ := stats.Load(path); st.Played != 0 {
+		t.Fatalf("Quitting a game recorded %v games played.", st.Played)
+	}
+}
+
+func TestGiveUpBeforeGuessing(t *testing.T) {
+
+	output, err := runScript(t, "p\n/giveup\n\nq\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Nice try! The word was 'piety.'")
+
+	if strings.Contains(output, "Expected") {
+		t.Fatalf("An analysis table was shown for a game with no guesses. Output:\n%s", output)
+	}
+}
+
+func TestPlayAnalysis(t *testing.T) {
+
+	output, err := runScript(t, "p\ncrane\nwould\n\nq\n", "would")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Guess  Left", "CRANE  483 -> ", "WOULD  ")
+}
+
+func TestPlayRecordsStats(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "stats.json")
+	s := New(strings.NewReader("p\npiety\n\nq\n"), io.Discard, PlainRenderer{})
+	s.SelectAnswer = func() string { return "piety" }
+	s.StatsPath = path
+
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	st, err := stats.Load(path)
+
+	if err != nil {
+		t.Fatalf("Loading stats failed: %v", err)
+	}
+
+	if st.Played != 1 || st.Distribution[1] != 1 {
+		t.Fatalf("Playing recorded %v played with distribution %v.", st.Played, st.Distribution)
+	}
+}
+
+func TestPlayRecordsHistory(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	s := New(strings.NewReader("p\ncrane\nslate\naudio\nmount\nlight\nbrick\n\nq\n"), io.Discard, PlainRenderer{})
+	s.HistoryPath = path
+
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	records, err := history.Load(path)
+
+	if err != nil {
+		t.Fatalf("Loading history failed: %v", err)
+	}
+
+	if len(records) != 1 || records[0].Mode != "classic" || len(records[0].Results) != len(records[0].Guesses) {
+		t.Fatalf("Playing recorded history %v.", records)
+	}
+
+	if records[0].Seed == 0 || logic.SeededAnswer(records[0].Seed) != records[0].Answer {
+		t.Fatalf("Answer %q was not chosen from seed %v.", records[0].Answer, records[0].Seed)
+	}
+}
+
+func TestPlayRecordsBeforeEndOfInput(t *testing.T) {
+
+	dir := t.TempDir()
+	s := New(strings.NewReader("p\npiety\n"), io.Discard, PlainRenderer{})
+	s.SelectAnswer = func() string { return "piety" }
+	s.StatsPath = filepath.Join(dir, "stats.json")
+	s.HistoryPath = filepath.Join(dir, "history.jsonl")
+
+	// The input ends at "Hit enter", after the game is over
+	s.Run()
+
+	st, err := stats.Load(s.StatsPath)
+
+	if err != nil || st.Played != 1 {
+		t.Fatalf("The finished game was not recorded in stats: %v, %v.", st, err)
+	}
+
+	records, err := history.Load(s.HistoryPath)
+
+	if err != nil || len(records) != 1 {
+		t.Fatalf("The finished game was not recorded in history: %v, %v.", records, err)
+	}
+}
+
+func TestSessionEvents(t *testing.T) {
+
+	s := New(strings.NewReader("p\naaaaa\ncrane\npiety\n\nq\n"), io.Discard, PlainRenderer{})
+	s.SelectAnswer = func() string { return "piety" }
+
+	var seen []logic.EventType
+	s.Events.Subscribe(func(e logic.Event) { seen = append(seen, e.Type) })
+
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	if len(seen) != 5 || seen[0] != logic.GameStarted || seen[1] != logic.GuessRejected || seen[4] != logic.GameWon {
+		t.Fatalf("Playing emitted %v.", seen)
+	}
+}
+
+func TestFibbleCommands(t *testing.T) {
+
+	output, err := runScript(t, "f\ncrane\n/remaining\n/hint\n/help\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Answers remaining: ", "Try ", "allowing for the lies")
+}
+
+func TestHotSeat(t *testing.T) {
+
+	script := "h\nal\nbo\n1\n" +
+		// Bo sets, Al guesses in two
+		"bbbbb\npiety\ncrane\npiety\n\n" +
+		// Al sets, Bo misses
+		"fjord\ncrane\nslate\npiety\nabout\nhello\nworld\n\n" +
+		"\nq\n"
+
+	// Only a renderer that can conceal the secret words allows hot-seat
+	output, err := runSession(t, New(strings.NewReader(script), nil, ANSIRenderer{}), script, "")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Invalid word: must be a valid word.", "al: 2\n", "bo: 7\n", "al wins!")
+}
+
+func TestHotSeatNeedsHiddenInput(t *testing.T) {
+
+	output, err := runScript(t, "h\nal\nbo\n1\npiety\nq\n", "")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Hot-seat needs a terminal that can hide the secret word")
+
+	if strings.Contains(output, "enter the secret word") {
+		t.Fatalf("Hot-seat asked for a secret word it couldn't hide. Output:\n%s", output)
+	}
+}
+
+func TestHotSeatReadHidden(t *testing.T) {
+
+	script := "h\nal\nbo\n1\npiety\n\ncrane\nfjord\n\n\nq\n"
+	secrets := []string{"piety", "fjord"}
+
+	s := New(strings.NewReader(script), nil, PlainRenderer{})
+	s.ReadHidden = func() (string, error) {
+
+		secret := secrets[0]
+		secrets = secrets[1:]
+
+		return secret, nil
+	}
+
+	output, err := runSession(t, s, script, "")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "al: 1\n", "bo: 2\n", "al wins!")
+}
+
+func TestHotSeatQuit(t *testing.T) {
+
+	script := "h\nal\nbo\n2\npiety\npiety\n\nfjord\n/quit\nq\n"
+	output, err := runSession(t, New(strings.NewReader(script), nil, ANSIRenderer{}), script, "")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "Match abandoned. Scores so far (fewest guesses wins):", "al: 1\n", "bo: 0\n")
+}
+
+func TestSpeedrun(t *testing.T) {
+
+	output, err := runScript(t, "s\npiety\n\nq\n", "piety")
+
+	if err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, output, "[0:00.0] Guess: ", "Total time: 0:00.0", "Godle 1/6\n")
+}
+
+// A clock that moves a minute forward every time it is read.
+type steppingClock struct {
+	now time.Time
+}
+
+func (c *steppingClock) Now() time.Time {
+
+	c.now = c.now.Add(time.Minute)
+	return c.now
+}
+
+// Timers never fire, so time only runs out when the clock is read.
+func (c *steppingClock) After(d time.Duration) <-chan time.Time {
+
+	return nil
+}
+
+func TestCountdownTimeOut(t *testing.T) {
+
+	var out bytes.Buffer
+
+	s := New(strings.NewReader("c\np\n10\ncrane\n\nq\n"), &out, PlainRenderer{})
+	s.SelectAnswer = func() string { return "piety" }
+	s.Clock = &steppingClock{now: time.Unix(0, 0)}
+
+	var seen []logic.EventType
+	s.Events.Subscribe(func(e logic.Event) { seen = append(seen, e.Type) })
+
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	expectOutput(t, out.String(), "Out of time! The word was 'piety.'")
+
+	if len(seen) == 0 || seen[len(seen)-1] != logic.GameLost {
+		t.Fatalf("Running out of time emitted %v, expected it to end with GameLost.", seen)
+	}
+}
+
+// A clock that never moves, whose timers all fire when the test says so.
+type alarmClock struct {
+	fixedClock
+	alarm chan time.Time
+}
+
+func (c alarmClock) After(d time.Duration) <-chan time.Time {
+
+	return c.alarm
+}
+
+func TestCountdownTimeOutWithoutInput(t *testing.T) {
+
+	in, input := io.Pipe()
+	var out bytes.Buffer
+
+	s := New(in, &out, PlainRenderer{})
+	s.SelectAnswer = func() string { return "piety" }
+	clock := alarmClock{alarm: make(chan time.Time)}
+	s.Clock = clock
+
+	var seen []logic.EventType
+	s.Events.Subscribe(func(e logic.Event) { seen = append(seen, e.Type) })
+
+	done := make(chan error)
+	go func() { done <- s.Run() }()
+
+	// The player starts a countdown, then never types a guess
+	io.WriteString(input, "c\np\n10\n")
+
+	select {
+	case clock.alarm <- time.Unix(10, 0):
+	case <-time.After(5 * time.Second):
+		t.Fatalf("The countdown never waited on its deadline.")
+	}
+
+	io.WriteString(input, "\nq\n")
+	input.Close()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() returned an error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run() did not return after the countdown ran out.")
+	}
+
+	expectOutput(t, out.String(), "Out of time! The word was 'piety.'", "Thanks for playing!")
+
+	if len(seen) == 0 || seen[len(seen)-1] != logic.GameLost {
+		t.Fatalf("Running out of time emitted %v, expected it to end with GameLost.", seen)
+	}
+}
+
+// A writer that notices when a write starts before the last one ends.
+type overlapWriter struct {
+	writing  atomic.Bool
+	overlaps atomic.Int32
+}
+
+func (w *overlapWriter) Write(p []byte) (int, error) {
+
+	if !w.writing.CompareAndSwap(false, true) {
+
+		w.overlaps.Add(1)
+		return len(p), nil
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	w.writing.Store(false)
+
+	return len(p), nil
+}
+
+func TestLiveTimerDoesNotInterleave(t *testing.T) {
+
+	in, input := io.Pipe()
+	out := &overlapWriter{}
+
+	s := New(in, out, ANSIRenderer{})
+	s.SelectAnswer = func() string { return "piety" }
+	s.Terminal = true
+
+	// Type slowly enough for the timer to be drawn between guesses
+	go func() {
+
+		for _, line := range []string{"s", "crane", "slate", "fjord", "piety", "", "q"} {
+
+			time.Sleep(150 * time.Millisecond)
+			io.WriteString(input, line+"\n")
+		}
+
+		input.Close()
+	}()
+
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	if n := out.overlaps.Load(); n > 0 {
+		t.Fatalf("The timer wrote %v times while other output was being written.", n)
+	}
+}
+
+func TestAssist(t *testing.T) {
+
+	var out bytes.Buffer
+
+	s := New(strings.NewReader("crane xx\ncrane bbbbb\nsloth bbbbg\n/quit\n"), &out, PlainRenderer{})
+
+	if err := s.Assist(); err != nil {
+		t.Fatalf("Assist() returned an error: %v", err)
+	}
+
+	expectOutput(t, out.String(), "Invalid entry: ", "Answers remaining: 33", "Best next guesses:", "CRANE bbbbb\nSLOTH bbbbg\n")
+}
+
+func TestPlainRenderer(t *testing.T) {
+
+	r := PlainRenderer{}
+
+	if r.Hint("A", 0) != "." || r.Hint("A", 1) != "A" || r.Hint("A", 2) != "a" {
+		t.Fatalf("Hint() returned %s, %s, %s.", r.Hint("A", 0), r.Hint("A", 1), r.Hint("A", 2))
+	}
+}
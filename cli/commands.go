@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Returned when the player leaves a game part way through.
+var errQuitGame = errors.New("quit game")
+
+// The commands that can be typed at the guess prompt, with their help.
+var gameCommands = [][2]string{
+	{"/hint", "reveal a letter of the answer"},
+	{"/hint word", "suggest a word to guess next"},
+	{"/giveup", "reveal the answer and count the game as lost"},
+	{"/remaining", "count the answers that fit every result so far"},
+	{"/board", "show the board again"},
+	{"/quit", "leave the game without recording it"},
+	{"/help", "show these commands"},
+}
+
+// Runs input typed at the guess prompt as a command if it starts with a
+// slash. Returns false if it isn't a command, so should be guessed, and
+// errQuitGame if the player asked to leave the game.
+func (s *Session) runGameCommand(game *logic.Game, input string) (bool, error) {
+
+	if !strings.HasPrefix(input, "/") {
+		return false, nil
+	}
+
+	switch strings.Join(strings.Fields(strings.ToLower(input)), " ") {
+
+	case "/hint":
+		position, letter, err := game.RevealLetter()
+
+		if err != nil {
+			s.say("No hint: %v.", err)
+		} else {
+			s.say("Letter %v is %c.", position+1, letter)
+		}
+
+	case "/hint word":
+		suggest := game.SuggestWord
+
+		if s.Strategy != nil {
+			suggest = func() (string, error) { return game.SuggestGuess(s.Strategy) }
+		}
+
+		word, err := suggest()
+
+		if err != nil {
+			s.say("No hint: %v.", err)
+		} else {
+			s.say("Try %s.", strings.ToUpper(word))
+		}
+
+	case "/giveup":
+		game.GiveUp()
+
+	case "/remaining":
+		s.say("Answers remaining: %v", len(game.Remaining()))
+
+	case "/board":
+		s.printResults(game.Guesses, game.Results)
+		s.printAvailableLetters(game.UsedLetters)
+		s.printKnowledge(game.Guesses, game.Results)
+
+	case "/quit":
+		return true, errQuitGame
+
+	case "/help":
+		for _, command := range gameCommands {
+			s.say("%-12s %s", command[0], command[1])
+		}
+
+	default:
+		s.say("Unknown command %s. Type /help to see the commands.", input)
+	}
+
+	return true, nil
+}
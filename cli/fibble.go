@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// The commands that can be typed at the guess prompt of a lying game.
+// Hints allow for one lie in every row, so they can't be fooled.
+var fibbleCommands = [][2]string{
+	{"/hint", "suggest a word that fits every result, allowing for the lies"},
+	{"/remaining", "count the answers that fit every result, allowing for the lies"},
+	{"/board", "show the board again"},
+	{"/help", "show these commands"},
+}
+
+// Start a game in which one tile of every row lies.
+func (s *Session) playFibble() error {
+
+	game := logic.NewFibbleGame()
+	record := s.startRecord("fibble", 0)
+
+	s.say("Guess the word! One tile in every row is lying. Type /help for commands.")
+
+	for !game.IsOver() {
+
+		guess, err := s.promptLine(fmt.Sprintf("Guess (%v/%v): ", len(game.Guesses)+1, logic.FibbleMaxGuesses))
+
+		if err != nil {
+			return err
+		}
+
+		if s.runFibbleCommand(game, guess) {
+			continue
+		}
+
+		err = game.MakeGuess(guess)
+
+		if err != nil {
+
+			s.say("Invalid guess: %v.", err)
+
+		} else {
+
+			s.printResults(game.Guesses, game.Displayed)
+			s.printAvailableLetters(game.UsedLetters)
+		}
+	}
+
+	outcome := Outcome{
+		Won:        game.HasWon(),
+		Guesses:    len(game.Guesses),
+		MaxGuesses: logic.FibbleMaxGuesses,
+	}
+
+	if !outcome.Won {
+		outcome.Answers = []string{game.Answer}
+	}
+
+	record.Hints = game.Hints
+	s.recordHistory(record, outcome.Won, game.Answer, game.Guesses, game.Results)
+
+	s.renderer.GameOver(s.out, outcome)
+	s.printLies(game)
+	return s.waitForEnter()
+}
+
+// Prints every row as it was shown next to the truth,
+// with the lying tile pointed out underneath.
+func (s *Session) printLies(game *logic.FibbleGame) {
+
+	s.say("The lies were:")
+
+	for i, guess := range game.Guesses {
+
+		s.renderer.Lie(s.out, guess, game.Displayed[i], game.Results[i], game.Lies[i])
+	}
+}
+
+// Runs input typed at the guess prompt of a lying game as a command if
+// it starts with a slash. Returns false if it isn't a command.
+func (s *Session) runFibbleCommand(game *logic.FibbleGame, input string) bool {
+
+	if !strings.HasPrefix(input, "/") {
+		return false
+	}
+
+	switch strings.Join(strings.Fields(strings.ToLower(input)), " ") {
+
+	case "/hint":
+		strategy := s.Strategy
+
+		if strategy == nil {
+			strategy = logic.Strategies["first"]
+		}
+
+		word, err := game.SuggestGuess(strategy)
+
+		if err != nil {
+			s.say("No hint: %v.", err)
+		} else {
+			s.say("Try %s.", strings.ToUpper(word))
+		}
+
+	case "/remaining":
+		s.say("Answers remaining: %v", len(game.Remaining()))
+
+	case "/board":
+		s.printResults(game.Guesses, game.Displayed)
+		s.printAvailableLetters(game.UsedLetters)
+
+	case "/help":
+		for _, command := range fibbleCommands {
+			s.say("%-12s %s", command[0], command[1])
+		}
+
+	default:
+		s.say("Unknown command %s. Type /help to see the commands.", input)
+	}
+
+	return true
+}
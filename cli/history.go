@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"math/rand"
+
+	"github.com/Dannflower/godle/history"
+	"github.com/Dannflower/godle/logic"
+)
+
+// Starts a classic game, returning it with the seed its answer was
+// chosen from, or zero if the answer was chosen by SelectAnswer.
+func (s *Session) newGame() (*logic.Game, int64) {
+
+	if s.SelectAnswer != nil {
+		return logic.CreateObservedGame(s.SelectAnswer(), s.Events), 0
+	}
+
+	// Zero is kept to mean there was no seed
+	seed := rand.Int63()
+
+	for seed == 0 {
+		seed = rand.Int63()
+	}
+
+	return logic.CreateObservedGame(logic.SeededAnswer(seed), s.Events), seed
+}
+
+// Starts the history record of a game in the given mode.
+func (s *Session) startRecord(mode string, seed int64) history.Record {
+
+	return history.Record{Mode: mode, Seed: seed, Started: s.Clock.Now()}
+}
+
+// Finishes the record of a game and appends it to the session's history
+// file. Failing to do so is reported but never interrupts the game.
+func (s *Session) recordHistory(record history.Record, won bool, answer string, guesses []string, results [][]int) {
+
+	if s.HistoryPath == "" {
+		return
+	}
+
+	record.Won = won
+	record.Answer = answer
+	record.Guesses = guesses
+	record.Results = results
+	record.Finished = s.Clock.Now()
+
+	if err := history.Append(s.HistoryPath, record); err != nil {
+		s.say("Could not record history: %v.", err)
+	}
+}
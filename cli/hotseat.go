@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// A player in a hot-seat game.
+type player struct {
+	name    string
+	guesses int
+}
+
+// Start a game where two players take turns setting
+// the word for each other on the same terminal.
+func (s *Session) playHotSeat() error {
+
+	// Without a way to hide it, the secret word would be shown to the guesser
+	if !s.canHideInput() {
+
+		s.say("Hot-seat needs a terminal that can hide the secret word, which this output format can't do.")
+		return nil
+	}
+
+	var players []*player
+
+	for _, prompt := range []string{"Player one's name: ", "Player two's name: "} {
+
+		name, err := s.promptLine(prompt)
+
+		if err != nil {
+			return err
+		}
+
+		players = append(players, &player{name: name})
+	}
+
+	rounds, err := s.promptRounds()
+
+	if err != nil {
+		return err
+	}
+
+	for round := 1; round <= rounds; round++ {
+
+		for i, guesser := range players {
+
+			setter := players[(i+1)%len(players)]
+
+			s.say("Round %v of %v: %s sets the word, %s guesses.", round, rounds, setter.name, guesser.name)
+			game, err := s.promptAnswer(setter)
+
+			if err != nil {
+				return err
+			}
+
+			record := s.startRecord("hot-seat", 0)
+			won, err := s.playRound(game)
+
+			// Leaving a round ends the whole match
+			if errors.Is(err, errQuitGame) {
+
+				s.say("Match abandoned. Scores so far (fewest guesses wins):")
+				s.printScores(players)
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			record.Hints = game.Hints
+
+			s.recordHistory(record, won, game.Answer, game.Guesses, game.Results)
+
+			// Failing to guess the word costs one more than the maximum
+			if won {
+				guesser.guesses += len(game.Guesses)
+			} else {
+				guesser.guesses += logic.MaxGuesses + 1
+			}
+
+			if err := s.waitForEnter(); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.say("Final scores (fewest guesses wins):")
+	s.printScores(players)
+
+	switch {
+	case players[0].guesses < players[1].guesses:
+		s.say("%s wins!", players[0].name)
+	case players[1].guesses < players[0].guesses:
+		s.say("%s wins!", players[1].name)
+	default:
+		s.say("It's a tie!")
+	}
+
+	return s.waitForEnter()
+}
+
+// Shows the guesses each player has taken so far.
+func (s *Session) printScores(players []*player) {
+
+	for _, p := range players {
+
+		s.say("%s: %v", p.name, p.guesses)
+	}
+}
+
+// Asks the setter for a secret word with the typed text hidden,
+// and returns a new game with it once a valid word is given.
+func (s *Session) promptAnswer(setter *player) (*logic.Game, error) {
+
+	for {
+
+		text, err := s.promptHidden(fmt.Sprintf("%s, enter the secret word (the other player should look away): ", setter.name))
+
+		if err != nil {
+			return nil, err
+		}
+
+		game, err := logic.CreateGameWithAnswer(strings.TrimSpace(text), s.Events)
+
+		if err == nil {
+			return game, nil
+		}
+
+		s.say("Invalid word: %v.", err)
+	}
+}
+
+// Asks the players how many rounds to play until a positive number is given.
+func (s *Session) promptRounds() (int, error) {
+
+	for {
+
+		text, err := s.promptLine("Rounds: ")
+
+		if err != nil {
+			return 0, err
+		}
+
+		rounds, err := strconv.Atoi(text)
+
+		if err == nil && rounds > 0 {
+			return rounds, nil
+		}
+
+		s.say("Invalid number of rounds.")
+	}
+}
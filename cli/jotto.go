@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Start a game where guesses only reveal letter counts.
+func (s *Session) playJotto() error {
+
+	game := logic.NewJottoGame()
+	record := s.startRecord("jotto", 0)
+
+	s.say("Guess the word! You'll only be told how many letters are green and yellow.")
+
+	for !game.IsOver() {
+
+		guess, err := s.promptLine(fmt.Sprintf("Guess (%v/%v): ", len(game.Guesses)+1, game.MaxGuesses))
+
+		if err != nil {
+			return err
+		}
+
+		err = game.MakeGuess(guess)
+
+		if err != nil {
+
+			s.say("Invalid guess: %v.", err)
+
+		} else {
+
+			s.printFeedback(game.Guesses, game.Feedback)
+			s.printAvailableLetters(game.UsedLetters)
+		}
+	}
+
+	outcome := Outcome{
+		Won:        game.HasWon(),
+		Guesses:    len(game.Guesses),
+		MaxGuesses: game.MaxGuesses,
+	}
+
+	if !outcome.Won {
+		outcome.Answers = []string{game.Answer}
+	}
+
+	// Jotto feedback isn't given letter by letter, so there are no results
+	s.recordHistory(record, outcome.Won, game.Answer, game.Guesses, nil)
+
+	return s.handleGameOver(outcome)
+}
+
+// Prints each guess alongside its feedback, whatever the kind of feedback.
+func (s *Session) printFeedback(guesses []string, feedback []logic.Feedback) {
+
+	for i, guess := range guesses {
+
+		switch f := feedback[i].(type) {
+
+		case logic.PositionalFeedback:
+			s.renderer.Board(s.out, Board{Guesses: []string{guess}, Results: [][]int{f}})
+
+		case logic.AggregateFeedback:
+			s.say("%s  %s %s", strings.ToUpper(guess),
+				s.renderer.Hint(fmt.Sprintf("%v green", f.Correct), logic.CorrectPosition),
+				s.renderer.Hint(fmt.Sprintf("%v yellow", f.WrongPosition), logic.WrongPosition))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Start a run of words played on a shared pool of guesses.
+func (s *Session) playMarathon() error {
+
+	m := logic.NewMarathon()
+
+	s.say("Solve as many words as you can with %v guesses!", m.Pool)
+	s.say("Solving a word early adds the guesses you didn't need back to the pool.")
+
+	for !m.IsOver() {
+
+		record := s.startRecord("marathon", 0)
+
+		for !m.WordOver() {
+
+			guess, err := s.promptLine(fmt.Sprintf("Guess (%v left): ", m.Pool))
+
+			if err != nil {
+				return err
+			}
+
+			err = m.MakeGuess(guess)
+
+			if err != nil {
+
+				s.say("Invalid guess: %v.", err)
+
+			} else {
+
+				s.printResults(m.Current.Guesses, m.Current.Results)
+				s.printAvailableLetters(m.Current.UsedLetters)
+				s.printKnowledge(m.Current.Guesses, m.Current.Results)
+			}
+		}
+
+		s.recordHistory(record, m.Current.HasWon(), m.Current.Answer, m.Current.Guesses, m.Current.Results)
+
+		if m.Current.HasWon() {
+
+			s.say("You got it! Words solved: %v", m.Solved)
+
+		} else {
+
+			s.say("The word was '%s.'", m.Current.Answer)
+		}
+
+		if !m.IsOver() && m.NextWord() != nil {
+
+			s.say("You've played every word!")
+			break
+		}
+	}
+
+	s.say("The run is over!")
+	s.say("Words solved: %v", m.Solved)
+	s.say("Average guesses: %.2f", m.AverageGuesses())
+	return s.waitForEnter()
+}
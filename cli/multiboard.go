@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Start a game in which every guess is played on several boards.
+func (s *Session) playMulti() error {
+
+	boards, err := s.promptBoardCount()
+
+	if err != nil {
+		return err
+	}
+
+	game, err := logic.NewMultiGame(boards)
+
+	if err != nil {
+
+		s.say("Could not start game: %v.", err)
+		return nil
+	}
+
+	record := s.startRecord("multi", 0)
+
+	s.say("Solve all %v words in %v guesses!", len(game.Boards), game.MaxGuesses)
+
+	for !game.IsOver() {
+
+		guess, err := s.promptLine(fmt.Sprintf("Guess (%v/%v): ", len(game.Guesses)+1, game.MaxGuesses))
+
+		if err != nil {
+			return err
+		}
+
+		err = game.MakeGuess(guess)
+
+		if err != nil {
+
+			s.say("Invalid guess: %v.", err)
+
+		} else {
+
+			s.printBoards(game)
+		}
+	}
+
+	outcome := Outcome{
+		Won:        game.HasWon(),
+		Guesses:    len(game.Guesses),
+		MaxGuesses: game.MaxGuesses,
+		Headline:   "You got them all!",
+	}
+
+	if !outcome.Won {
+
+		outcome.Headline = fmt.Sprintf("Nice try! You solved %v/%v.", game.Solved(), len(game.Boards))
+
+		for _, board := range game.Boards {
+
+			outcome.Answers = append(outcome.Answers, board.Answer)
+		}
+	}
+
+	// Each board is recorded as a game of its own
+	for _, board := range game.Boards {
+
+		s.recordHistory(record, board.HasWon(), board.Answer, board.Guesses, board.Results)
+	}
+
+	return s.handleGameOver(outcome)
+}
+
+// Asks the player how many boards to play until a supported count is given.
+func (s *Session) promptBoardCount() (int, error) {
+
+	options := make([]string, len(logic.BoardCounts))
+
+	for i, count := range logic.BoardCounts {
+
+		options[i] = strconv.Itoa(count)
+	}
+
+	for {
+
+		text, err := s.promptLine(fmt.Sprintf("Boards (%s): ", strings.Join(options, "/")))
+
+		if err != nil {
+			return 0, err
+		}
+
+		boards, err := strconv.Atoi(strings.TrimSpace(text))
+
+		if err == nil {
+
+			for _, count := range logic.BoardCounts {
+
+				if boards == count {
+					return boards, nil
+				}
+			}
+		}
+
+		s.say("Invalid number of boards.")
+	}
+}
+
+// Shows every board side by side, each with the
+// guesses played on it and its own keyboard tracker.
+func (s *Session) printBoards(game *logic.MultiGame) {
+
+	boards := make([]Board, len(game.Boards))
+
+	for i, board := range game.Boards {
+
+		boards[i] = Board{
+			Title:       fmt.Sprintf("#%v", i+1),
+			Guesses:     board.Guesses,
+			Results:     board.Results,
+			UsedLetters: board.UsedLetters,
+			Solved:      board.HasWon(),
+		}
+	}
+
+	s.renderer.Boards(s.out, boards, int(s.width.Load()))
+}
@@ -0,0 +1,590 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/Dannflower/godle/logic"
+
+	"github.com/fatih/color"
+)
+
+// The letters of the keyboard tracker, split into its two rows.
+var keyboardRows = []string{"ABCDEFGHIJKLM", "NOPQRSTUVWXYZ"}
+
+// The width of a single board column side by side, wide
+// enough for one row of the keyboard tracker.
+const boardWidth = 13
+
+// The space printed between boards side by side.
+const boardGap = "   "
+
+// A board to show the player: the guesses made on it
+// and what they revealed.
+type Board struct {
+	Title       string
+	Guesses     []string
+	Results     [][]int
+	UsedLetters map[rune]int
+	Solved      bool
+}
+
+// The outcome of a finished game.
+type Outcome struct {
+	Won bool
+	// The answers revealed to the player.
+	Answers []string
+	Guesses int
+	// Zero if the number of guesses wasn't limited.
+	MaxGuesses int
+	// Replaces the usual headline, if set.
+	Headline string
+}
+
+// Decides how everything in a session is shown to the player,
+// keeping the presentation apart from the flow of the games.
+type Renderer interface {
+	// Writes the guesses made on a board with their results.
+	Board(w io.Writer, board Board)
+	// Writes several boards at once, fitting them into the given width.
+	Boards(w io.Writer, boards []Board, width int)
+	// Writes the letters of the alphabet marked with what is known about them.
+	Keyboard(w io.Writer, usedLetters map[rune]int)
+	// Writes a summary of what the results so far have revealed.
+	Knowledge(w io.Writer, k *logic.Knowledge)
+	// Writes a line of text.
+	Message(w io.Writer, text string)
+	// Writes a prompt for input. Hidden prompts are for input
+	// the other players shouldn't see.
+	Prompt(w io.Writer, text string, hidden bool)
+	// Clears anything hidden input may have left behind.
+	Clear(w io.Writer)
+	// Writes the outcome of a finished game.
+	GameOver(w io.Writer, outcome Outcome)
+	// Writes a guess with the result it was shown next to its true
+	// result, pointing out the letter at the lie position.
+	Lie(w io.Writer, guess string, shown []int, truth []int, lie int)
+	// Returns the text marked up to show the given hint.
+	Hint(text string, hint int) string
+}
+
+// Implemented by renderers whose hidden prompts keep what is typed out
+// of sight, rather than writing them like any other prompt.
+type inputConcealer interface {
+	concealsInput()
+}
+
+// Holds a lock for everything another renderer writes, so that
+// output written from other goroutines never lands part way through.
+type lockedRenderer struct {
+	Renderer
+	mu *sync.Mutex
+}
+
+func (r lockedRenderer) Board(w io.Writer, board Board) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.Board(w, board)
+}
+
+func (r lockedRenderer) Boards(w io.Writer, boards []Board, width int) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.Boards(w, boards, width)
+}
+
+func (r lockedRenderer) Keyboard(w io.Writer, usedLetters map[rune]int) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.Keyboard(w, usedLetters)
+}
+
+func (r lockedRenderer) Knowledge(w io.Writer, k *logic.Knowledge) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.Knowledge(w, k)
+}
+
+func (r lockedRenderer) Message(w io.Writer, text string) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.Message(w, text)
+}
+
+func (r lockedRenderer) Prompt(w io.Writer, text string, hidden bool) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.Prompt(w, text, hidden)
+}
+
+func (r lockedRenderer) Clear(w io.Writer) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.Clear(w)
+}
+
+func (r lockedRenderer) GameOver(w io.Writer, outcome Outcome) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.GameOver(w, outcome)
+}
+
+func (r lockedRenderer) Lie(w io.Writer, guess string, shown []int, truth []int, lie int) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Renderer.Lie(w, guess, shown, truth, lie)
+}
+
+// Shows everything as text with hints in ANSI colors.
+type ANSIRenderer struct{}
+
+// ANSI codes to hide typed text and to clear the screen.
+const (
+	concealText = "\033[8m"
+	revealText  = "\033[0m"
+	clearScreen = "\033[H\033[2J"
+)
+
+func (r ANSIRenderer) Board(w io.Writer, board Board) {
+
+	for i, guess := range board.Guesses {
+
+		fmt.Fprintln(w, markGuess(r, guess, board.Results[i]))
+	}
+}
+
+func (r ANSIRenderer) Boards(w io.Writer, boards []Board, width int) {
+
+	writeBoardsSideBySide(w, r, boards, width)
+}
+
+func (r ANSIRenderer) Keyboard(w io.Writer, usedLetters map[rune]int) {
+
+	writeKeyboard(w, r, usedLetters)
+}
+
+func (r ANSIRenderer) Knowledge(w io.Writer, k *logic.Knowledge) {
+
+	writeKnowledge(w, r, k)
+}
+
+func (ANSIRenderer) Message(w io.Writer, text string) {
+
+	fmt.Fprintln(w, text)
+}
+
+func (ANSIRenderer) Prompt(w io.Writer, text string, hidden bool) {
+
+	if hidden {
+		text += concealText
+	}
+
+	fmt.Fprint(w, text)
+}
+
+func (ANSIRenderer) concealsInput() {}
+
+func (ANSIRenderer) Clear(w io.Writer) {
+
+	fmt.Fprint(w, revealText+clearScreen)
+}
+
+func (ANSIRenderer) GameOver(w io.Writer, outcome Outcome) {
+
+	writeOutcome(w, outcome)
+}
+
+func (r ANSIRenderer) Lie(w io.Writer, guess string, shown []int, truth []int, lie int) {
+
+	writeLie(w, r, guess, shown, truth, lie)
+}
+
+func (ANSIRenderer) Hint(text string, hint int) string {
+
+	switch hint {
+
+	case logic.NotInWord:
+		return color.HiBlackString(text)
+
+	case logic.WrongPosition:
+		return color.YellowString(text)
+
+	case logic.CorrectPosition:
+		return color.GreenString(text)
+
+	default:
+		return text
+	}
+}
+
+// Shows everything as plain text, for output that isn't a terminal.
+// Within the text letters in the correct position are capitalized,
+// letters in the wrong position are lower case and letters not in
+// the word are replaced with a dot.
+type PlainRenderer struct{}
+
+// Boards are written with each guess next to its pattern, in which
+// 'g' is green, 'y' is yellow and 'b' is gray.
+func (PlainRenderer) Board(w io.Writer, board Board) {
+
+	for i, guess := range board.Guesses {
+
+		fmt.Fprintf(w, "%s %s\n", strings.ToUpper(guess), logic.FormatPattern(board.Results[i]))
+	}
+}
+
+func (r PlainRenderer) Boards(w io.Writer, boards []Board, width int) {
+
+	writeBoardsSideBySide(w, r, boards, width)
+}
+
+func (r PlainRenderer) Keyboard(w io.Writer, usedLetters map[rune]int) {
+
+	writeKeyboard(w, r, usedLetters)
+}
+
+func (r PlainRenderer) Knowledge(w io.Writer, k *logic.Knowledge) {
+
+	writeKnowledge(w, r, k)
+}
+
+func (PlainRenderer) Message(w io.Writer, text string) {
+
+	fmt.Fprintln(w, text)
+}
+
+func (PlainRenderer) Prompt(w io.Writer, text string, hidden bool) {
+
+	fmt.Fprint(w, text)
+}
+
+func (PlainRenderer) Clear(w io.Writer) {}
+
+func (PlainRenderer) GameOver(w io.Writer, outcome Outcome) {
+
+	writeOutcome(w, outcome)
+}
+
+func (r PlainRenderer) Lie(w io.Writer, guess string, shown []int, truth []int, lie int) {
+
+	writeLie(w, r, guess, shown, truth, lie)
+}
+
+func (PlainRenderer) Hint(text string, hint int) string {
+
+	switch hint {
+
+	case logic.NotInWord:
+		return strings.Repeat(".", len(text))
+
+	case logic.WrongPosition:
+		return strings.ToLower(text)
+
+	case logic.CorrectPosition:
+		return strings.ToUpper(text)
+
+	default:
+		return text
+	}
+}
+
+// Writes the guess with every letter marked up by its result.
+func markGuess(r Renderer, guess string, result []int) string {
+
+	marked := ""
+
+	for i, letter := range strings.ToUpper(guess) {
+
+		marked += r.Hint(string(letter), result[i])
+	}
+
+	return marked
+}
+
+// Writes the guess marked up as it was shown and as it should have been,
+// with a caret under the letter that lied. The renderer must mark each
+// letter up as a single column.
+func writeLie(w io.Writer, r Renderer, guess string, shown []int, truth []int, lie int) {
+
+	fmt.Fprintf(w, "%s  %s\n", markGuess(r, guess, shown), markGuess(r, guess, truth))
+	fmt.Fprintf(w, "%s^\n", strings.Repeat(" ", lie))
+}
+
+// Returns the given keys, each marked up by its state in usedLetters
+// and followed by the separator.
+func markKeys(r Renderer, keys string, usedLetters map[rune]int, separator string) string {
+
+	marked := ""
+
+	for _, key := range keys {
+
+		if hint, ok := usedLetters[key]; ok {
+
+			marked += r.Hint(string(key), hint)
+
+		} else {
+
+			marked += string(key)
+		}
+
+		marked += separator
+	}
+
+	return marked
+}
+
+// Writes out the complete list of letters with any
+// used in previous guesses marked up by their hint.
+func writeKeyboard(w io.Writer, r Renderer, usedLetters map[rune]int) {
+
+	for _, keys := range keyboardRows {
+
+		fmt.Fprintln(w, markKeys(r, keys, usedLetters, " "))
+	}
+}
+
+// Writes the summary of what is known as text: the letters fixed in
+// place, the letters known to be in the word but not where, the letters
+// in the word known not to be at each position and the repeated letters.
+// Lines with nothing to show are left out.
+func writeKnowledge(w io.Writer, r Renderer, k *logic.Knowledge) {
+
+	fmt.Fprintf(w, "Known:     %s\n", knownPattern(r, k))
+
+	if misplaced := k.Misplaced(); len(misplaced) > 0 {
+		fmt.Fprintf(w, "Somewhere: %s\n", markLetters(r, misplaced, logic.WrongPosition))
+	}
+
+	if excluded := excludedPositions(k); len(excluded) > 0 {
+		fmt.Fprintf(w, "Not at:    %s\n", strings.Join(excluded, "  "))
+	}
+
+	if repeated := k.Repeated(); len(repeated) > 0 {
+		fmt.Fprintf(w, "Repeated:  %s\n", markLetters(r, repeated, logic.WrongPosition))
+	}
+}
+
+// Returns the letters fixed in place with an underscore at every
+// position still unknown, e.g. "C _ A _ E".
+func knownPattern(r Renderer, k *logic.Knowledge) string {
+
+	cells := make([]string, len(k.Fixed))
+
+	for i, letter := range k.Fixed {
+
+		if letter == 0 {
+			cells[i] = "_"
+		} else {
+			cells[i] = r.Hint(string(letter), logic.CorrectPosition)
+		}
+	}
+
+	return strings.Join(cells, " ")
+}
+
+// Returns, for each position not yet fixed, the letters known to be in
+// the word but not at that position, e.g. "2: E R". Letters not in the
+// word at all are left out since the keyboard already shows them.
+func excludedPositions(k *logic.Knowledge) []string {
+
+	var positions []string
+
+	for i, excluded := range k.Excluded {
+
+		if k.Fixed[i] != 0 {
+			continue
+		}
+
+		var letters []string
+
+		for _, keys := range keyboardRows {
+
+			for _, letter := range keys {
+
+				if excluded[letter] && k.MinCount[letter] > 0 {
+					letters = append(letters, string(letter))
+				}
+			}
+		}
+
+		if len(letters) > 0 {
+			positions = append(positions, fmt.Sprintf("%v: %s", i+1, strings.Join(letters, " ")))
+		}
+	}
+
+	return positions
+}
+
+// Returns the letters separated by spaces, each marked up with the hint.
+func markLetters(r Renderer, letters []rune, hint int) string {
+
+	marked := make([]string, len(letters))
+
+	for i, letter := range letters {
+		marked[i] = r.Hint(string(letter), hint)
+	}
+
+	return strings.Join(marked, " ")
+}
+
+// Writes the usual text for the end of a game.
+func writeOutcome(w io.Writer, outcome Outcome) {
+
+	if !outcome.Won {
+
+		headline := outcome.Headline
+
+		if headline == "" {
+			headline = "Nice try!"
+		}
+
+		switch len(outcome.Answers) {
+		case 0:
+			fmt.Fprintln(w, headline)
+		case 1:
+			fmt.Fprintf(w, "%s The word was '%s.'\n", headline, outcome.Answers[0])
+		default:
+			fmt.Fprintf(w, "%s The words were '%s.'\n", headline, strings.Join(outcome.Answers, "', '"))
+		}
+
+		return
+	}
+
+	headline := outcome.Headline
+
+	if headline == "" {
+		headline = "You got it!"
+	}
+
+	fmt.Fprintln(w, headline)
+
+	if outcome.MaxGuesses > 0 {
+
+		fmt.Fprintf(w, "Guesses: %v/%v\n", outcome.Guesses, outcome.MaxGuesses)
+
+	} else {
+
+		fmt.Fprintf(w, "Guesses: %v\n", outcome.Guesses)
+	}
+}
+
+// Writes the boards side by side, as many to a row as fit in the width,
+// each with the guesses played on it and its own keyboard tracker.
+func writeBoardsSideBySide(w io.Writer, r Renderer, boards []Board, width int) {
+
+	boardsPerRow := (width + len(boardGap)) / (boardWidth + len(boardGap))
+
+	if boardsPerRow < 1 {
+		boardsPerRow = 1
+	}
+
+	for start := 0; start < len(boards); start += boardsPerRow {
+
+		end := start + boardsPerRow
+
+		if end > len(boards) {
+			end = len(boards)
+		}
+
+		row := boards[start:end]
+		rows := 0
+
+		// Header
+		var cells []string
+
+		for _, board := range row {
+
+			header := board.Title
+
+			if board.Solved {
+				header += " solved"
+			}
+
+			if len(board.Guesses) > rows {
+				rows = len(board.Guesses)
+			}
+
+			cells = append(cells, padCell(header, len(header)))
+		}
+
+		fmt.Fprintln(w, strings.Join(cells, boardGap))
+
+		// Boards solved early are left blank after their last guess
+		for i := 0; i < rows; i++ {
+
+			cells = nil
+
+			for _, board := range row {
+
+				if i < len(board.Guesses) {
+
+					cell := markGuess(r, board.Guesses[i], board.Results[i])
+					cells = append(cells, padCell(cell, len(board.Guesses[i])))
+
+				} else {
+
+					cells = append(cells, padCell("", 0))
+				}
+			}
+
+			fmt.Fprintln(w, strings.Join(cells, boardGap))
+		}
+
+		for _, keys := range keyboardRows {
+
+			cells = nil
+
+			for _, board := range row {
+
+				cells = append(cells, markKeys(r, keys, board.UsedLetters, ""))
+			}
+
+			fmt.Fprintln(w, strings.Join(cells, boardGap))
+		}
+
+		fmt.Fprintln(w)
+	}
+}
+
+// Pads a cell whose visible width is width out to the board
+// width. The visible width is passed in since the cell may
+// contain ANSI escape codes.
+func padCell(cell string, width int) string {
+
+	if width >= boardWidth {
+		return cell
+	}
+
+	return cell + strings.Repeat(" ", boardWidth-width)
+}
+
+// The renderers that can be chosen by name.
+var renderers = map[string]Renderer{
+	"ansi":     ANSIRenderer{},
+	"plain":    PlainRenderer{},
+	"json":     JSONRenderer{},
+	"markdown": MarkdownRenderer{},
+}
+
+// Returns the renderer with the given name: ansi, plain, json or markdown.
+func RendererNamed(name string) (Renderer, error) {
+
+	r, ok := renderers[strings.ToLower(name)]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q", name)
+	}
+
+	return r, nil
+}
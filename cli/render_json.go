@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// The names used for each hint in JSON output.
+var hintNames = map[int]string{
+	logic.NotInWord:       "absent",
+	logic.WrongPosition:   "present",
+	logic.CorrectPosition: "correct",
+}
+
+// Writes everything as JSON lines, one object per line with a "type"
+// field, for front ends and bots driving the game from another program.
+type JSONRenderer struct{}
+
+// A guess and its result in JSON output.
+type jsonRow struct {
+	Guess   string `json:"guess"`
+	Pattern string `json:"pattern"`
+}
+
+// A board in JSON output.
+type jsonBoard struct {
+	Title  string    `json:"title,omitempty"`
+	Rows   []jsonRow `json:"rows"`
+	Solved bool      `json:"solved"`
+}
+
+func (r JSONRenderer) Board(w io.Writer, board Board) {
+
+	writeJSON(w, struct {
+		Type string `json:"type"`
+		jsonBoard
+	}{"board", toJSONBoard(board)})
+}
+
+func (r JSONRenderer) Boards(w io.Writer, boards []Board, width int) {
+
+	jsonBoards := make([]jsonBoard, len(boards))
+
+	for i, board := range boards {
+
+		jsonBoards[i] = toJSONBoard(board)
+	}
+
+	writeJSON(w, struct {
+		Type   string      `json:"type"`
+		Boards []jsonBoard `json:"boards"`
+	}{"boards", jsonBoards})
+}
+
+func (JSONRenderer) Keyboard(w io.Writer, usedLetters map[rune]int) {
+
+	letters := make(map[string]string)
+
+	for letter, hint := range usedLetters {
+
+		letters[string(letter)] = hintNames[hint]
+	}
+
+	writeJSON(w, struct {
+		Type    string            `json:"type"`
+		Letters map[string]string `json:"letters"`
+	}{"keyboard", letters})
+}
+
+func (JSONRenderer) Knowledge(w io.Writer, k *logic.Knowledge) {
+
+	pattern := make([]byte, len(k.Fixed))
+
+	for i, letter := range k.Fixed {
+
+		if letter == 0 {
+			pattern[i] = '_'
+		} else {
+			pattern[i] = byte(letter)
+		}
+	}
+
+	excluded := make(map[int]string)
+
+	for i, letters := range k.Excluded {
+
+		for _, keys := range keyboardRows {
+
+			for _, letter := range keys {
+
+				if letters[letter] {
+					excluded[i+1] += string(letter)
+				}
+			}
+		}
+	}
+
+	writeJSON(w, struct {
+		Type      string         `json:"type"`
+		Pattern   string         `json:"pattern"`
+		Misplaced string         `json:"misplaced"`
+		Excluded  map[int]string `json:"excluded"`
+		Repeated  string         `json:"repeated"`
+		Absent    string         `json:"absent"`
+		MinCount  map[string]int `json:"min_count"`
+		Exact     map[string]int `json:"exact_count"`
+	}{"knowledge", string(pattern), string(k.Misplaced()), excluded, string(k.Repeated()),
+		string(k.Absent()), letterCounts(k.MinCount), letterCounts(k.ExactCount)})
+}
+
+func (JSONRenderer) Message(w io.Writer, text string) {
+
+	writeJSON(w, struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}{"message", text})
+}
+
+func (JSONRenderer) Prompt(w io.Writer, text string, hidden bool) {
+
+	writeJSON(w, struct {
+		Type   string `json:"type"`
+		Text   string `json:"text"`
+		Hidden bool   `json:"hidden"`
+	}{"prompt", strings.TrimSpace(text), hidden})
+}
+
+func (JSONRenderer) Clear(w io.Writer) {}
+
+func (JSONRenderer) GameOver(w io.Writer, outcome Outcome) {
+
+	writeJSON(w, struct {
+		Type       string   `json:"type"`
+		Won        bool     `json:"won"`
+		Answers    []string `json:"answers"`
+		Guesses    int      `json:"guesses"`
+		MaxGuesses int      `json:"max_guesses,omitempty"`
+		Headline   string   `json:"headline,omitempty"`
+	}{"game_over", outcome.Won, outcome.Answers, outcome.Guesses, outcome.MaxGuesses, outcome.Headline})
+}
+
+func (JSONRenderer) Lie(w io.Writer, guess string, shown []int, truth []int, lie int) {
+
+	writeJSON(w, struct {
+		Type     string `json:"type"`
+		Guess    string `json:"guess"`
+		Shown    string `json:"shown"`
+		Truth    string `json:"truth"`
+		Position int    `json:"position"`
+	}{"lie", guess, logic.FormatPattern(shown), logic.FormatPattern(truth), lie})
+}
+
+// Hints are left out of inline text, since boards carry them separately.
+func (JSONRenderer) Hint(text string, hint int) string {
+
+	return text
+}
+
+// Converts counts of each letter to their JSON form.
+func letterCounts(counts map[rune]int) map[string]int {
+
+	converted := make(map[string]int)
+
+	for letter, count := range counts {
+		converted[string(letter)] = count
+	}
+
+	return converted
+}
+
+// Converts a board to its JSON form.
+func toJSONBoard(board Board) jsonBoard {
+
+	rows := make([]jsonRow, len(board.Guesses))
+
+	for i, guess := range board.Guesses {
+
+		rows[i] = jsonRow{Guess: strings.ToLower(guess), Pattern: logic.FormatPattern(board.Results[i])}
+	}
+
+	return jsonBoard{Title: board.Title, Rows: rows, Solved: board.Solved}
+}
+
+// Writes the value as a single line of JSON.
+func writeJSON(w io.Writer, v interface{}) {
+
+	json.NewEncoder(w).Encode(v)
+}
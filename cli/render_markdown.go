@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Writes everything as Markdown, for pasting games into chat and
+// documents. Boards are written as share squares next to each guess.
+type MarkdownRenderer struct{}
+
+func (MarkdownRenderer) Board(w io.Writer, board Board) {
+
+	if board.Title != "" {
+		fmt.Fprintf(w, "#### %s\n\n", board.Title)
+	}
+
+	for i, guess := range board.Guesses {
+
+		squares := logic.Share{Results: board.Results[i : i+1]}.Squares()
+		fmt.Fprintf(w, "- `%s` %s\n", strings.ToUpper(guess), squares)
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (r MarkdownRenderer) Boards(w io.Writer, boards []Board, width int) {
+
+	for _, board := range boards {
+
+		if board.Solved {
+			board.Title += " (solved)"
+		}
+
+		r.Board(w, board)
+	}
+}
+
+func (r MarkdownRenderer) Keyboard(w io.Writer, usedLetters map[rune]int) {
+
+	fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(markKeys(r, strings.Join(keyboardRows, ""), usedLetters, " ")))
+}
+
+func (r MarkdownRenderer) Knowledge(w io.Writer, k *logic.Knowledge) {
+
+	var text strings.Builder
+	writeKnowledge(&text, r, k)
+
+	for _, line := range strings.Split(strings.TrimSpace(text.String()), "\n") {
+
+		label, value, _ := strings.Cut(line, ":")
+		fmt.Fprintf(w, "- %s: %s\n", label, strings.TrimSpace(value))
+	}
+
+	fmt.Fprintln(w)
+}
+
+func (MarkdownRenderer) Message(w io.Writer, text string) {
+
+	// A trailing double space keeps the line break
+	fmt.Fprintf(w, "%s  \n", text)
+}
+
+func (MarkdownRenderer) Prompt(w io.Writer, text string, hidden bool) {
+
+	fmt.Fprintf(w, "**%s** ", strings.TrimSpace(text))
+}
+
+func (MarkdownRenderer) Clear(w io.Writer) {}
+
+func (MarkdownRenderer) GameOver(w io.Writer, outcome Outcome) {
+
+	var text strings.Builder
+	writeOutcome(&text, outcome)
+
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+	fmt.Fprintf(w, "### %s\n\n", lines[0])
+
+	for _, line := range lines[1:] {
+
+		fmt.Fprintf(w, "%s  \n", line)
+	}
+
+	fmt.Fprintln(w)
+}
+
+// Marked up letters aren't a column wide, so the lie is named instead.
+func (MarkdownRenderer) Lie(w io.Writer, guess string, shown []int, truth []int, lie int) {
+
+	shownSquares := logic.Share{Results: [][]int{shown}}.Squares()
+	trueSquares := logic.Share{Results: [][]int{truth}}.Squares()
+	fmt.Fprintf(w, "- `%s` showed %s instead of %s, letter %v lied  \n", strings.ToUpper(guess), shownSquares, trueSquares, lie+1)
+}
+
+// Correct letters are bold, misplaced letters are
+// italic and letters not in the word are struck out.
+func (MarkdownRenderer) Hint(text string, hint int) string {
+
+	switch hint {
+
+	case logic.NotInWord:
+		return "~~" + text + "~~"
+
+	case logic.WrongPosition:
+		return "_" + text + "_"
+
+	case logic.CorrectPosition:
+		return "**" + text + "**"
+
+	default:
+		return text
+	}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+func TestJSONRendererBoard(t *testing.T) {
+
+	var out bytes.Buffer
+
+	JSONRenderer{}.Board(&out, Board{Guesses: []string{"crane"}, Results: [][]int{{0, 0, 0, 0, 2}}})
+
+	var line struct {
+		Type string
+		Rows []struct{ Guess, Pattern string }
+	}
+
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+		t.Fatalf("Board() wrote invalid JSON %q: %v.", out.String(), err)
+	}
+
+	if line.Type != "board" || len(line.Rows) != 1 || line.Rows[0].Guess != "crane" || line.Rows[0].Pattern != "bbbby" {
+		t.Fatalf("Board() wrote %q.", out.String())
+	}
+}
+
+func TestJSONRendererSession(t *testing.T) {
+
+	var out bytes.Buffer
+
+	s := New(strings.NewReader("p\ncrane\npiety\n\nq\n"), &out, JSONRenderer{})
+	s.SelectAnswer = func() string { return "piety" }
+
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run() returned %v.", err)
+	}
+
+	types := make(map[string]int)
+
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+
+		var v struct{ Type string }
+
+		if err := json.Unmarshal([]byte(line), &v); err != nil {
+			t.Fatalf("Line %q is not valid JSON: %v.", line, err)
+		}
+
+		types[v.Type]++
+	}
+
+	if types["board"] != 2 || types["keyboard"] != 2 || types["game_over"] != 1 || types["prompt"] == 0 {
+		t.Fatalf("Unexpected lines written: %v.", types)
+	}
+}
+
+func TestMarkdownRenderer(t *testing.T) {
+
+	var out bytes.Buffer
+	r := MarkdownRenderer{}
+
+	r.Board(&out, Board{Guesses: []string{"crane"}, Results: [][]int{{0, 0, 0, 0, 2}}})
+	r.GameOver(&out, Outcome{Won: true, Guesses: 1, MaxGuesses: 6})
+
+	expectOutput(t, out.String(), "- `CRANE` ⬛⬛⬛⬛🟨\n", "### You got it!\n", "Guesses: 1/6  \n")
+
+	if r.Hint("A", 1) != "**A**" {
+		t.Fatalf("Hint() returned %q.", r.Hint("A", 1))
+	}
+}
+
+func TestKnowledgePanel(t *testing.T) {
+
+	game := logic.CreateGame("speed")
+	game.MakeGuess("erase")
+
+	var out bytes.Buffer
+	PlainRenderer{}.Knowledge(&out, game.Knowledge())
+
+	expected := "Known:     _ _ _ _ _\n" +
+		"Somewhere: e s\n" +
+		"Not at:    1: E  4: S  5: E\n" +
+		"Repeated:  e\n"
+
+	if out.String() != expected {
+		t.Fatalf("Knowledge() wrote:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+
+	out.Reset()
+	JSONRenderer{}.Knowledge(&out, game.Knowledge())
+
+	var line struct{ Type, Pattern, Repeated string }
+
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil || line.Type != "knowledge" || line.Pattern != "_____" || line.Repeated != "E" {
+		t.Fatalf("Knowledge() wrote %q.", out.String())
+	}
+}
+
+func TestRendererLie(t *testing.T) {
+
+	shown := []int{logic.NotInWord, logic.CorrectPosition, logic.NotInWord, logic.NotInWord, logic.NotInWord}
+	truth := []int{logic.NotInWord, logic.NotInWord, logic.NotInWord, logic.NotInWord, logic.NotInWord}
+
+	var out bytes.Buffer
+	PlainRenderer{}.Lie(&out, "crane", shown, truth, 1)
+
+	if out.String() != ".R...  .....\n ^\n" {
+		t.Fatalf("PlainRenderer wrote the lie as %q.", out.String())
+	}
+
+	// The caret can't line up under bold letters, so the letter is named
+	out.Reset()
+	MarkdownRenderer{}.Lie(&out, "crane", shown, truth, 1)
+
+	expectOutput(t, out.String(), "- `CRANE` showed ⬛🟩⬛⬛⬛ instead of ⬛⬛⬛⬛⬛, letter 2 lied  \n")
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Dannflower/godle/logic"
+	"github.com/Dannflower/godle/stats"
+)
+
+// Records a finished game in the session's stats file. Failing
+// to do so is reported but never interrupts the game.
+func (s *Session) recordGame(won bool, guesses int, hints int, elapsed time.Duration) {
+
+	if s.StatsPath == "" {
+		return
+	}
+
+	err := stats.Update(s.StatsPath, func(st *stats.Stats) {
+
+		st.Record(won, guesses, elapsed)
+		st.RecordHints(hints)
+	})
+
+	if err != nil {
+		s.say("Could not record stats: %v.", err)
+	}
+}
+
+// Prints the totals from the session's stats file.
+func (s *Session) printStats() error {
+
+	err := errors.New("no stats file")
+
+	if s.StatsPath != "" {
+
+		var st *stats.Stats
+		st, err = stats.Load(s.StatsPath)
+
+		if err == nil {
+
+			s.say("Played: %v", st.Played)
+			s.say("Won: %v", st.Won)
+			s.say("Current streak: %v", st.CurrentStreak)
+			s.say("Max streak: %v", st.MaxStreak)
+			s.say("Guess distribution:")
+
+			for guesses := 1; guesses <= logic.MaxGuesses; guesses++ {
+
+				s.say("%v: %v", guesses, st.Distribution[guesses])
+			}
+
+			if st.TimedGames > 0 {
+
+				s.say("Best time: %s", logic.FormatDuration(st.BestTime))
+				s.say("Average time: %s", logic.FormatDuration(st.AverageTime()))
+			}
+
+			if st.Hints > 0 {
+				s.say("Hints taken: %v in %v games", st.Hints, st.HintedGames)
+			}
+		}
+	}
+
+	if err != nil {
+		s.say("Could not load stats: %v.", err)
+	}
+
+	return s.waitForEnter()
+}
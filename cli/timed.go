@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Dannflower/godle/history"
+	"github.com/Dannflower/godle/logic"
+)
+
+// The default time budgets for countdown games.
+const (
+	defaultPuzzleBudget = 3 * time.Minute
+	defaultGuessBudget  = 30 * time.Second
+)
+
+// How often the live timer is redrawn.
+const timerRefresh = 100 * time.Millisecond
+
+// The room left for the live timer at the end of the first line.
+const timerWidth = 20
+
+// Start a game timed from the first prompt to the last guess.
+func (s *Session) playSpeedrun() error {
+
+	game, seed := s.newGame()
+	record := s.startRecord("speedrun", seed)
+
+	return s.playTimedRound(game, record, logic.NewStopwatch(s.Clock), nil)
+}
+
+// Start a game that is lost if the time budget runs out.
+func (s *Session) playCountdown() error {
+
+	limit, err := s.promptLine("Time limit per (p)uzzle or per (g)uess? ")
+
+	if err != nil {
+		return err
+	}
+
+	perGuess := limit == "g"
+	budget := defaultPuzzleBudget
+
+	if perGuess {
+		budget = defaultGuessBudget
+	}
+
+	budget, err = s.promptBudget(budget)
+
+	if err != nil {
+		return err
+	}
+
+	game, seed := s.newGame()
+	record := s.startRecord("countdown", seed)
+	countdown := logic.NewCountdown(s.Clock, budget, perGuess)
+
+	return s.playTimedRound(game, record, countdown.Stopwatch, countdown)
+}
+
+// Plays the game against the stopwatch. If countdown is not nil,
+// running out of time before the answer is found is a loss.
+func (s *Session) playTimedRound(game *logic.Game, record history.Record, watch *logic.Stopwatch, countdown *logic.Countdown) error {
+
+	stopTimer := s.startLiveTimer(watch, countdown)
+
+	s.say("Guess the word! The clock is running.")
+
+	for !game.IsOver() {
+
+		// Time runs out even while the player isn't typing
+		var deadline <-chan time.Time
+
+		if countdown != nil {
+			deadline = s.Clock.After(countdown.Remaining())
+		}
+
+		guess, err := s.promptLineUntil(fmt.Sprintf("[%s] Guess: ", timerText(watch, countdown)), deadline)
+
+		timedOut := errors.Is(err, errNoInput)
+
+		if timedOut {
+
+			// Nothing was typed, so finish the prompt's line
+			s.say("")
+
+		} else if err != nil {
+
+			stopTimer()
+			return err
+		}
+
+		if timedOut || countdown != nil && countdown.Expired() {
+
+			game.TimeOut()
+			break
+		}
+
+		if command, err := s.runGameCommand(game, guess); command {
+
+			if errors.Is(err, errQuitGame) {
+
+				stopTimer()
+				return nil
+			}
+
+			continue
+		}
+
+		err = game.MakeGuess(guess)
+
+		if err != nil {
+
+			s.say("Invalid guess: %v.", err)
+			continue
+		}
+
+		split := watch.Lap()
+
+		s.printResults(game.Guesses, game.Results)
+		s.printAvailableLetters(game.UsedLetters)
+		s.printKnowledge(game.Guesses, game.Results)
+		s.say("Guess time: %s", logic.FormatDuration(split))
+	}
+
+	won := game.HasWon()
+
+	stopTimer()
+	elapsed := watch.Elapsed()
+
+	outcome := Outcome{
+		Won:        won,
+		Guesses:    len(game.Guesses),
+		MaxGuesses: logic.MaxGuesses,
+	}
+
+	if !won {
+		outcome.Answers = []string{game.Answer}
+	}
+
+	if game.TimedOut {
+		outcome.Headline = "Out of time!"
+	}
+
+	s.renderer.GameOver(s.out, outcome)
+
+	for i, split := range watch.Splits {
+
+		s.say("Guess %v: %s", i+1, logic.FormatDuration(split))
+	}
+
+	s.say("Total time: %s", logic.FormatDuration(elapsed))
+	s.say("")
+	s.say("%s", logic.Share{
+		Title:      "Godle",
+		Results:    game.Results,
+		Won:        won,
+		MaxGuesses: logic.MaxGuesses,
+		Elapsed:    elapsed,
+		Hints:      game.Hints,
+	})
+	s.say("")
+	s.printAnalysis(game)
+
+	s.recordGame(won, len(game.Guesses), game.Hints, elapsed)
+	record.Hints = game.Hints
+	s.recordHistory(record, won, game.Answer, game.Guesses, game.Results)
+
+	return s.waitForEnter()
+}
+
+// Returns the time to show the player: time remaining
+// on a countdown, otherwise the time elapsed.
+func timerText(watch *logic.Stopwatch, countdown *logic.Countdown) string {
+
+	if countdown != nil {
+		return logic.FormatDuration(countdown.Remaining()) + " left"
+	}
+
+	return logic.FormatDuration(watch.Elapsed())
+}
+
+// Keeps the timer drawn in the top right corner of the terminal
+// until the returned function is called. Nothing is drawn when
+// the session isn't on a terminal.
+func (s *Session) startLiveTimer(watch *logic.Stopwatch, countdown *logic.Countdown) func() {
+
+	if !s.Terminal {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+
+		ticker := time.NewTicker(timerRefresh)
+		defer ticker.Stop()
+		defer close(stopped)
+
+		for {
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// Save the cursor, draw in the corner, then restore it
+				column := int(s.width.Load()) - timerWidth
+
+				if column < 1 {
+					column = 1
+				}
+
+				s.outMu.Lock()
+				fmt.Fprintf(s.out, "\0337\033[1;%vH[%s]\033[K\0338", column, timerText(watch, countdown))
+				s.outMu.Unlock()
+			}
+		}
+	}()
+
+	return func() {
+
+		close(done)
+		<-stopped
+	}
+}
+
+// Asks for a time budget in seconds, using the given default
+// if nothing is entered.
+func (s *Session) promptBudget(defaultBudget time.Duration) (time.Duration, error) {
+
+	for {
+
+		text, err := s.promptLine(fmt.Sprintf("Seconds [%v]: ", defaultBudget.Seconds()))
+
+		if err != nil {
+			return 0, err
+		}
+
+		if text == "" {
+			return defaultBudget, nil
+		}
+
+		seconds, err := strconv.Atoi(text)
+
+		if err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second, nil
+		}
+
+		s.say("Invalid number of seconds.")
+	}
+}
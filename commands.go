@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/Dannflower/godle/cli"
+	"github.com/Dannflower/godle/logic"
+	"github.com/Dannflower/godle/server"
+
+	"github.com/fatih/color"
+)
+
+// Runs the named command line command with its arguments,
+// exiting with a non-zero status if it fails.
+func runCommand(name string, args []string) {
+
+	var err error
+
+	switch name {
+	case "serve":
+		err = serveCommand(args)
+	case "ssh":
+		err = sshCommand(args)
+	case "history":
+		err = historyCommand(args)
+	case "assist":
+		err = assistCommand(args)
+	case "find":
+		err = findCommand(args)
+	case "openers":
+		err = openersCommand(args)
+	case "tree":
+		err = treeCommand(args)
+	case "grid":
+		err = gridCommand(args)
+	default:
+		err = fmt.Errorf("unknown command %q", name)
+	}
+
+	if err != nil {
+
+		fmt.Fprintf(os.Stderr, "godle %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+// Suggests guesses for a puzzle played in another client.
+func assistCommand(args []string) error {
+
+	flags := flag.NewFlagSet("assist", flag.ExitOnError)
+	format := flags.String("format", "ansi", "output format: ansi, plain, json or markdown")
+	strategyName := flags.String("strategy", "", "also show the guess picked by this strategy: "+strings.Join(logic.StrategyNames(), ", "))
+	treePath := flags.String("tree", "", "decision tree file written by godle tree to look the next guess up in")
+	flags.Parse(args)
+
+	renderer, err := cli.RendererNamed(*format)
+
+	if err != nil {
+		return err
+	}
+
+	session := cli.New(os.Stdin, color.Output, renderer)
+
+	if *strategyName != "" {
+
+		if session.Strategy, err = logic.StrategyNamed(*strategyName); err != nil {
+			return err
+		}
+	}
+
+	if *treePath != "" {
+
+		f, err := os.Open(*treePath)
+
+		if err != nil {
+			return err
+		}
+
+		session.Tree, err = logic.ReadDecisionTree(f)
+		f.Close()
+
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", *treePath, err)
+		}
+	}
+
+	return session.Assist()
+}
+
+// Hosts multiplayer races until the process is stopped.
+func serveCommand(args []string) error {
+
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", ":7777", "address to listen on")
+	flags.Parse(args)
+
+	listener, err := net.Listen("tcp", *addr)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Racing on %v\n", listener.Addr())
+
+	return server.New().Serve(listener)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Searches the dictionaries for words matching a pattern.
+func findCommand(args []string) error {
+
+	flags := flag.NewFlagSet("find", flag.ExitOnError)
+	require := flags.String("require", "", "letters the word must contain")
+	exclude := flags.String("exclude", "", "letters the word must not contain")
+	notAt := flags.String("not", "", "letters not allowed at positions, e.g. 1:ab,3:e")
+	answers := flags.Bool("answers", false, "only search the answer words")
+	order := flags.String("sort", logic.SortAlphabetical, "order of the words: alpha, frequency or answer")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: godle find [options] [pattern]")
+		fmt.Fprintln(flags.Output(), "The pattern has one character per letter, with ? for any letter, e.g. ?r?ne.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() > 1 {
+		return fmt.Errorf("expected one pattern, got %v", flags.NArg())
+	}
+
+	positions, err := parsePositions(*notAt)
+
+	if err != nil {
+		return err
+	}
+
+	words, err := logic.Find(logic.Query{
+		Pattern:     flags.Arg(0),
+		Required:    *require,
+		Excluded:    *exclude,
+		NotAt:       positions,
+		AnswersOnly: *answers,
+		Sort:        *order,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	isAnswer := logic.AnswerSet()
+
+	for _, word := range words {
+
+		kind := "valid"
+
+		if isAnswer[strings.ToLower(word)] {
+			kind = "answer"
+		}
+
+		fmt.Fprintf(table, "%s\t%s\n", word, kind)
+	}
+
+	table.Flush()
+
+	if len(words) == 1 {
+		fmt.Fprintln(os.Stderr, "1 word found")
+	} else {
+		fmt.Fprintf(os.Stderr, "%v words found\n", len(words))
+	}
+
+	return nil
+}
+
+// Parses letters excluded at positions, given as "1:ab,3:e" with
+// positions counted from one, into letters keyed by position from zero.
+func parsePositions(text string) (map[int]string, error) {
+
+	positions := make(map[int]string)
+
+	if text == "" {
+		return positions, nil
+	}
+
+	for _, part := range strings.Split(text, ",") {
+
+		position, letters, ok := strings.Cut(part, ":")
+		n, err := strconv.Atoi(strings.TrimSpace(position))
+
+		if !ok || err != nil {
+			return nil, fmt.Errorf("expected position:letters, got %q", part)
+		}
+
+		positions[n-1] += strings.TrimSpace(letters)
+	}
+
+	return positions, nil
+}
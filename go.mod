@@ -1,11 +1,15 @@
 module github.com/Dannflower/godle
 
-go 1.17
+go 1.23.0
 
-require github.com/fatih/color v1.17.0
+require (
+	github.com/fatih/color v1.17.0
+	golang.org/x/crypto v0.35.0
+	golang.org/x/term v0.29.0
+)
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.24.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
 )
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Lists the guesses that could have given each row of a share grid.
+func gridCommand(args []string) error {
+
+	flags := flag.NewFlagSet("grid", flag.ExitOnError)
+	answer := flags.String("answer", "", "the answer of the puzzle the grid was shared from")
+	limit := flags.Int("limit", 10, "number of words to list for each row, or 0 for all")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: godle grid -answer word [file]")
+		fmt.Fprintln(flags.Output(), "The share grid is read from the file, or standard input if none is given.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if len(*answer) != logic.WordLength {
+		return fmt.Errorf("expected a %v letter answer", logic.WordLength)
+	}
+
+	if flags.NArg() > 1 {
+		return fmt.Errorf("expected one file, got %v", flags.NArg())
+	}
+
+	text, err := readInput(flags.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	results, err := logic.ParseSquares(text)
+
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		return errors.New("no rows of squares found")
+	}
+
+	if logic.ValidateWord(*answer) != nil {
+		fmt.Printf("%s isn't in the dictionary, so the grid may be from another one.\n", strings.ToUpper(*answer))
+	}
+
+	impossible := 0
+
+	for i, result := range results {
+
+		row := logic.Share{Results: [][]int{result}}.Squares()
+		guesses := logic.GuessesGiving(*answer, result)
+
+		if len(guesses) == 0 {
+
+			fmt.Printf("Row %v %s: impossible, no valid word gives this against %s\n", i+1, row, strings.ToUpper(*answer))
+			impossible++
+			continue
+		}
+
+		words := "words"
+
+		if len(guesses) == 1 {
+			words = "word"
+		}
+
+		fmt.Printf("Row %v %s: %v %s\n", i+1, row, len(guesses), words)
+
+		shown := guesses
+
+		if *limit > 0 && *limit < len(shown) {
+			shown = shown[:*limit]
+		}
+
+		fmt.Printf("  %s", strings.ToUpper(strings.Join(shown, " ")))
+
+		if len(shown) < len(guesses) {
+			fmt.Printf(" and %v more", len(guesses)-len(shown))
+		}
+
+		fmt.Println()
+	}
+
+	if impossible > 0 {
+		return fmt.Errorf("%v of %v rows are impossible, which suggests cheating or a different dictionary", impossible, len(results))
+	}
+
+	return nil
+}
+
+// Returns the contents of the file at path, or of standard input if path is empty.
+func readInput(path string) (string, error) {
+
+	var data []byte
+	var err error
+
+	if path == "" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	return string(data), err
+}
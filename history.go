@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Dannflower/godle/history"
+	"github.com/Dannflower/godle/logic"
+	"github.com/Dannflower/godle/stats"
+)
+
+// The layout of dates given to the history filters.
+const dateLayout = "2006-01-02"
+
+// Lists or exports the games in the history file.
+func historyCommand(args []string) error {
+
+	if len(args) == 0 {
+		return errors.New("expected list, export or import")
+	}
+
+	action := args[0]
+
+	if action == "import" {
+		return importHistory(args[1:])
+	}
+
+	if action != "list" && action != "export" {
+		return fmt.Errorf("unknown action %q, expected list, export or import", action)
+	}
+
+	defaultPath, _ := history.DefaultPath()
+
+	flags := flag.NewFlagSet("history "+action, flag.ExitOnError)
+	path := flags.String("file", defaultPath, "history file to read")
+	mode := flags.String("mode", "", "only games in this mode")
+	answer := flags.String("answer", "", "only games with this answer")
+	outcome := flags.String("outcome", "", "only games that were won or lost")
+	since := flags.String("since", "", "only games finished on or after this date (YYYY-MM-DD)")
+	until := flags.String("until", "", "only games finished before this date (YYYY-MM-DD)")
+	format := flags.String("format", "json", "export format: json or csv")
+	flags.Parse(args[1:])
+
+	filter := history.Filter{Mode: *mode, Answer: *answer, Outcome: *outcome}
+
+	if filter.Outcome != "" && filter.Outcome != "won" && filter.Outcome != "lost" {
+		return fmt.Errorf("unknown outcome %q, expected won or lost", filter.Outcome)
+	}
+
+	var err error
+
+	if filter.Since, err = parseDate(*since); err != nil {
+		return err
+	}
+
+	if filter.Until, err = parseDate(*until); err != nil {
+		return err
+	}
+
+	records, err := history.Load(*path)
+
+	if err != nil {
+		return err
+	}
+
+	records = filter.Apply(records)
+
+	if action == "list" {
+		return listHistory(os.Stdout, records)
+	}
+
+	switch *format {
+	case "json":
+		return history.WriteJSON(os.Stdout, records)
+	case "csv":
+		return history.WriteCSV(os.Stdout, records)
+	default:
+		return fmt.Errorf("unknown format %q, expected json or csv", *format)
+	}
+}
+
+// Imports games played in other clients from their share text, adding
+// them to the history and stats files so they count towards streaks.
+func importHistory(args []string) error {
+
+	defaultHistory, _ := history.DefaultPath()
+	defaultStats, _ := stats.DefaultPath()
+
+	flags := flag.NewFlagSet("history import", flag.ExitOnError)
+	path := flags.String("file", defaultHistory, "history file to add the games to")
+	statsPath := flags.String("stats", defaultStats, "stats file to add the games to, or empty to leave the stats alone")
+	date := flags.String("date", "", "date the games were played (YYYY-MM-DD, default today)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: godle history import [options] [file]")
+		fmt.Fprintln(flags.Output(), "The share text is read from the file, or standard input if none is given.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() > 1 {
+		return fmt.Errorf("expected one file, got %v", flags.NArg())
+	}
+
+	played := time.Now()
+
+	if *date != "" {
+
+		var err error
+
+		if played, err = parseDate(*date); err != nil {
+			return err
+		}
+	}
+
+	text, err := readInput(flags.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	games, err := logic.ParseShares(text)
+
+	if err != nil {
+		return err
+	}
+
+	imported, skipped, err := history.Import(*path, *statsPath, games, played)
+
+	for _, game := range skipped {
+
+		if game.Puzzle == 0 {
+
+			fmt.Printf("Skipped a %s game with the same squares as one already imported.\n", game.Title)
+			continue
+		}
+
+		fmt.Printf("Skipped %s %v, which was already imported.\n", game.Title, game.Puzzle)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %v of %v games.\n", len(imported), len(games))
+
+	return nil
+}
+
+// Parses a date given to a history filter. An empty
+// date is the zero time, which doesn't filter anything.
+func parseDate(date string) (time.Time, error) {
+
+	if date == "" {
+		return time.Time{}, nil
+	}
+
+	return time.ParseInLocation(dateLayout, date, time.Local)
+}
+
+// Writes a table of the records, one game per line.
+func listHistory(w io.Writer, records []history.Record) error {
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FINISHED\tMODE\tANSWER\tOUTCOME\tGUESSES\tHINTS\tPUZZLE")
+
+	for _, record := range records {
+
+		outcome := "lost"
+
+		if record.Won {
+			outcome = "won"
+		}
+
+		// Only imported games have a puzzle number
+		puzzle := "-"
+
+		if record.Puzzle != 0 {
+			puzzle = strconv.Itoa(record.Puzzle)
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%v\t%s\n",
+			record.Finished.Local().Format("2006-01-02 15:04"),
+			record.Mode, record.Answer, outcome, strings.Join(record.Guesses, " "), record.Hints, puzzle)
+	}
+
+	return table.Flush()
+}
@@ -0,0 +1,232 @@
+// Package history keeps a record of every finished game in a
+// JSON lines file, one game per line, for looking back over later.
+package history
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dannflower/godle/internal/pathlock"
+	"github.com/Dannflower/godle/logic"
+)
+
+// A finished game.
+type Record struct {
+	// The mode the game was played in, such as "classic" or "absurdle".
+	Mode    string   `json:"mode"`
+	Answer  string   `json:"answer"`
+	Guesses []string `json:"guesses"`
+	// The result of each guess. It is empty for modes
+	// whose feedback isn't given letter by letter.
+	Results  [][]int   `json:"results,omitempty"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	// The seed the answer was chosen from, or zero if
+	// the answer wasn't chosen from a seed.
+	Seed int64 `json:"seed,omitempty"`
+	// The number of hints taken.
+	Hints int `json:"hints,omitempty"`
+	// The puzzle number of a game imported from another client's
+	// share text, or zero if it had none.
+	Puzzle int  `json:"puzzle,omitempty"`
+	Won    bool `json:"won"`
+}
+
+// Returns the path of the history file in the user's config directory.
+func DefaultPath() (string, error) {
+
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "godle", "history.jsonl"), nil
+}
+
+// Appends the record to the history file at path, creating
+// the file and its directory if needed. Records appended at the
+// same time from one process are written one after the other.
+func Append(path string, record Record) error {
+
+	unlock := pathlock.Lock(path)
+	defer unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(data, '\n'))
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Loads every record in the history file at path, oldest first.
+// If there is no file at path yet, no records are returned.
+func Load(path string) ([]Record, error) {
+
+	f, err := os.Open(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Reads records from JSON lines, skipping blank lines.
+func Read(r io.Reader) ([]Record, error) {
+
+	var records []Record
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	for line := 1; scanner.Scan(); line++ {
+
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" {
+			continue
+		}
+
+		var record Record
+
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("line %v: %w", line, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// Chooses which records to keep. Fields left at their
+// zero value match every record.
+type Filter struct {
+	Mode   string
+	Answer string
+	// "won" or "lost".
+	Outcome string
+	// Only games finished at or after Since and before Until.
+	Since time.Time
+	Until time.Time
+}
+
+// Returns true if the record is chosen by the filter.
+func (f Filter) Match(record Record) bool {
+
+	switch {
+	case f.Mode != "" && !strings.EqualFold(f.Mode, record.Mode):
+		return false
+	case f.Answer != "" && !strings.EqualFold(f.Answer, record.Answer):
+		return false
+	case f.Outcome == "won" && !record.Won, f.Outcome == "lost" && record.Won:
+		return false
+	case !f.Since.IsZero() && record.Finished.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !record.Finished.Before(f.Until):
+		return false
+	}
+
+	return true
+}
+
+// Returns the records chosen by the filter, in their original order.
+func (f Filter) Apply(records []Record) []Record {
+
+	var matched []Record
+
+	for _, record := range records {
+
+		if f.Match(record) {
+			matched = append(matched, record)
+		}
+	}
+
+	return matched
+}
+
+// Writes the records as an indented JSON array.
+func WriteJSON(w io.Writer, records []Record) error {
+
+	if records == nil {
+		records = []Record{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(records)
+}
+
+// The columns of CSV exports.
+var csvHeader = []string{"mode", "answer", "won", "guesses", "patterns", "started", "finished", "seed", "hints", "puzzle"}
+
+// Writes the records as CSV with a header row. Guesses and their
+// patterns are each joined into a single space separated column.
+func WriteCSV(w io.Writer, records []Record) error {
+
+	writer := csv.NewWriter(w)
+	writer.Write(csvHeader)
+
+	for _, record := range records {
+
+		patterns := make([]string, len(record.Results))
+
+		for i, result := range record.Results {
+
+			patterns[i] = logic.FormatPattern(result)
+		}
+
+		writer.Write([]string{
+			record.Mode,
+			record.Answer,
+			strconv.FormatBool(record.Won),
+			strings.Join(record.Guesses, " "),
+			strings.Join(patterns, " "),
+			record.Started.Format(time.RFC3339),
+			record.Finished.Format(time.RFC3339),
+			strconv.FormatInt(record.Seed, 10),
+			strconv.Itoa(record.Hints),
+			strconv.Itoa(record.Puzzle),
+		})
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,124 @@
+package history
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testRecords() []Record {
+
+	day := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	return []Record{
+		{Mode: "classic", Answer: "piety", Guesses: []string{"crane", "piety"}, Results: [][]int{{0, 0, 0, 0, 2}, {1, 1, 1, 1, 1}}, Started: day, Finished: day.Add(time.Minute), Seed: 42, Hints: 1, Puzzle: 1001, Won: true},
+		{Mode: "absurdle", Answer: "cigar", Guesses: []string{"crane"}, Results: [][]int{{1, 2, 2, 0, 0}}, Started: day.AddDate(0, 0, 1), Finished: day.AddDate(0, 0, 1)},
+	}
+}
+
+func TestAppendLoad(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "godle", "history.jsonl")
+
+	records, err := Load(path)
+
+	if err != nil || len(records) != 0 {
+		t.Fatalf("Load(%s) returned %v, %v for a missing file.", path, records, err)
+	}
+
+	for _, record := range testRecords() {
+
+		if err := Append(path, record); err != nil {
+			t.Fatalf("Append(%s) returned an error: %v", path, err)
+		}
+	}
+
+	records, err = Load(path)
+
+	if err != nil {
+		t.Fatalf("Load(%s) returned an error: %v", path, err)
+	}
+
+	if len(records) != 2 || records[0].Seed != 42 || records[0].Results[1][4] != 1 || records[1].Mode != "absurdle" {
+		t.Fatalf("Load(%s) returned %v.", path, records)
+	}
+}
+
+func TestFilter(t *testing.T) {
+
+	records := testRecords()
+
+	tests := []struct {
+		filter   Filter
+		expected int
+	}{
+		{Filter{}, 2},
+		{Filter{Mode: "Classic"}, 1},
+		{Filter{Outcome: "lost"}, 1},
+		{Filter{Answer: "piety", Outcome: "won"}, 1},
+		{Filter{Since: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)}, 1},
+		{Filter{Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, 0},
+	}
+
+	for _, test := range tests {
+
+		if matched := test.filter.Apply(records); len(matched) != test.expected {
+			t.Fatalf("Filter %+v matched %v records, expected %v.", test.filter, len(matched), test.expected)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+
+	var out bytes.Buffer
+
+	if err := WriteCSV(&out, testRecords()); err != nil {
+		t.Fatalf("WriteCSV returned an error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+
+	if len(lines) != 3 || lines[1] != "classic,piety,true,crane piety,bbbby ggggg,2024-03-01T12:00:00Z,2024-03-01T12:01:00Z,42,1,1001" {
+		t.Fatalf("WriteCSV wrote:\n%s", out.String())
+	}
+
+	out.Reset()
+
+	if err := WriteJSON(&out, testRecords()); err != nil {
+		t.Fatalf("WriteJSON returned an error: %v", err)
+	}
+
+	if !strings.HasPrefix(out.String(), "[\n  {\n    \"mode\": \"classic\"") {
+		t.Fatalf("WriteJSON wrote:\n%s", out.String())
+	}
+}
+
+func TestAppendConcurrent(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	record := testRecords()[0]
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+
+		wg.Add(1)
+
+		go func() {
+
+			defer wg.Done()
+
+			if err := Append(path, record); err != nil {
+				t.Errorf("Append(%s) returned an error: %v", path, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if records, err := Load(path); err != nil || len(records) != 50 {
+		t.Fatalf("Load(%s) returned %v records, %v after 50 appends at once.", path, len(records), err)
+	}
+}
@@ -0,0 +1,122 @@
+package history
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Dannflower/godle/logic"
+	"github.com/Dannflower/godle/stats"
+)
+
+// Adds games played in other clients, parsed from their share text, to
+// the history file at path and to the stats file at statsPath, so they
+// count towards streaks. The stats are left alone if statsPath is empty.
+// Games whose puzzle is already in the history are skipped and returned,
+// as are games without a puzzle number, such as Godle's own, whose header
+// and squares match a game already imported, since nothing else tells
+// them apart.
+// The stats are saved with every game that made it into the history,
+// even if a later one fails, so the two files always agree.
+func Import(path string, statsPath string, games []logic.SharedGame, played time.Time) (imported []Record, skipped []logic.SharedGame, err error) {
+
+	records, err := Load(path)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Stats that can't be read are found before anything is imported
+	if statsPath != "" {
+
+		if _, err := stats.Load(statsPath); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var added []logic.SharedGame
+
+	defer func() {
+
+		if statsPath == "" || len(added) == 0 {
+			return
+		}
+
+		saveErr := stats.Update(statsPath, func(st *stats.Stats) {
+
+			for _, game := range added {
+
+				st.Record(game.Won, len(game.Results), game.Elapsed)
+				st.RecordHints(game.Hints)
+			}
+		})
+
+		if err == nil {
+			err = saveErr
+		}
+	}()
+
+	for _, game := range games {
+
+		record := Record{
+			Mode:     strings.ToLower(game.Title),
+			Results:  game.Results,
+			Started:  played,
+			Finished: played,
+			Hints:    game.Hints,
+			Puzzle:   game.Puzzle,
+			Won:      game.Won,
+		}
+
+		if importedBefore(records, record) {
+
+			skipped = append(skipped, game)
+			continue
+		}
+
+		if err := Append(path, record); err != nil {
+			return imported, skipped, err
+		}
+
+		records = append(records, record)
+		imported = append(imported, record)
+		added = append(added, game)
+	}
+
+	return imported, skipped, nil
+}
+
+// Returns true if the records already hold the puzzle of the record or,
+// for a record without a puzzle number, a game with the same outcome,
+// hints and results.
+func importedBefore(records []Record, record Record) bool {
+
+	for _, r := range records {
+
+		if r.Mode != record.Mode || r.Puzzle != record.Puzzle {
+			continue
+		}
+
+		if record.Puzzle != 0 || r.Won == record.Won && r.Hints == record.Hints && sameResults(r.Results, record.Results) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns true if both lists hold the same results in the same order.
+func sameResults(a [][]int, b [][]int) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+
+		if logic.FormatPattern(a[i]) != logic.FormatPattern(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,133 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dannflower/godle/logic"
+	"github.com/Dannflower/godle/stats"
+)
+
+func testShares(t *testing.T) []logic.SharedGame {
+
+	text := "Wordle 1,234 3/6\n\n⬛🟨⬛⬛🟩\n⬛🟩🟩⬛🟩\n🟩🟩🟩🟩🟩\n\nWordle 1,235 X/6\n" + strings.Repeat("⬛🟨⬛⬛⬛\n", 6)
+	games, err := logic.ParseShares(text)
+
+	if err != nil || len(games) != 2 {
+		t.Fatalf("ParseShares returned %v games, %v.", len(games), err)
+	}
+
+	return games
+}
+
+func TestImport(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "history.jsonl")
+	statsPath := filepath.Join(dir, "stats.json")
+	played := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	imported, skipped, err := Import(path, statsPath, testShares(t), played)
+
+	if err != nil || len(imported) != 2 || len(skipped) != 0 {
+		t.Fatalf("Import returned %v imported, %v skipped, %v.", len(imported), len(skipped), err)
+	}
+
+	records, err := Load(path)
+
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Load(%s) returned %v, %v.", path, records, err)
+	}
+
+	if records[0].Mode != "wordle" || records[0].Puzzle != 1234 || !records[0].Won || !records[0].Finished.Equal(played) {
+		t.Fatalf("Import recorded the first game as %+v.", records[0])
+	}
+
+	st, err := stats.Load(statsPath)
+
+	if err != nil || st.Played != 2 || st.Won != 1 || st.Distribution[3] != 1 {
+		t.Fatalf("Import left the stats as %+v, %v.", st, err)
+	}
+
+	// Importing the same puzzles again changes nothing
+	imported, skipped, err = Import(path, statsPath, testShares(t), played)
+
+	if err != nil || len(imported) != 0 || len(skipped) != 2 {
+		t.Fatalf("Import again returned %v imported, %v skipped, %v.", len(imported), len(skipped), err)
+	}
+
+	records, _ = Load(path)
+	st, _ = stats.Load(statsPath)
+
+	if len(records) != 2 || st.Played != 2 {
+		t.Fatalf("Import again left %v records and %v games played.", len(records), st.Played)
+	}
+}
+
+func TestImportWithoutStats(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	if _, _, err := Import(path, "", testShares(t), time.Now()); err != nil {
+		t.Fatalf("Import without a stats file returned an error: %v", err)
+	}
+
+	if records, err := Load(path); err != nil || len(records) != 2 {
+		t.Fatalf("Load(%s) returned %v, %v.", path, records, err)
+	}
+}
+
+func TestImportFailure(t *testing.T) {
+
+	dir := t.TempDir()
+	statsPath := filepath.Join(dir, "stats.json")
+
+	// The history can't be written under a file
+	blocker := filepath.Join(dir, "blocker")
+
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(blocker, "history.jsonl")
+
+	if _, _, err := Import(path, statsPath, testShares(t), time.Now()); err == nil {
+		t.Fatalf("Import returned no error when the history couldn't be written.")
+	}
+
+	if st, err := stats.Load(statsPath); err != nil || st.Played != 0 {
+		t.Fatalf("Import recorded stats for games missing from the history: %+v, %v.", st, err)
+	}
+}
+
+func TestImportWithoutPuzzleNumbers(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	text := "Godle 2/6 💡1\n\n⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩\n\nGodle 2/6\n\n⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩\n"
+	games, err := logic.ParseShares(text)
+
+	if err != nil || len(games) != 2 {
+		t.Fatalf("ParseShares returned %v games, %v.", len(games), err)
+	}
+
+	// The games differ only by their hints, so neither is a repeat
+	imported, skipped, err := Import(path, "", games, time.Now())
+
+	if err != nil || len(imported) != 2 || len(skipped) != 0 {
+		t.Fatalf("Import returned %v imported, %v skipped, %v.", len(imported), len(skipped), err)
+	}
+
+	// Pasting them again can only be told apart by their squares
+	imported, skipped, err = Import(path, "", games, time.Now())
+
+	if err != nil || len(imported) != 0 || len(skipped) != 2 {
+		t.Fatalf("Import again returned %v imported, %v skipped, %v.", len(imported), len(skipped), err)
+	}
+
+	if records, err := Load(path); err != nil || len(records) != 2 {
+		t.Fatalf("Load(%s) returned %v records, %v.", path, len(records), err)
+	}
+}
@@ -0,0 +1,26 @@
+// Package pathlock serialises changes to files shared by the sessions
+// running in one process, such as SSH players using the same key.
+package pathlock
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// The lock of every path locked so far, keyed by its cleaned absolute form.
+var locks sync.Map
+
+// Locks the file at path until the returned function is called. Paths
+// naming the same file the same way share a lock.
+func Lock(path string) (unlock func()) {
+
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	lock, _ := locks.LoadOrStore(filepath.Clean(path), &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+
+	return mu.Unlock
+}
@@ -0,0 +1,38 @@
+package pathlock
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestLock(t *testing.T) {
+
+	dir := t.TempDir()
+	count := 0
+	var wg sync.WaitGroup
+
+	// The same file named two ways shares one lock
+	paths := []string{filepath.Join(dir, "stats.json"), filepath.Join(dir, ".", "stats.json")}
+
+	for i := 0; i < 100; i++ {
+
+		wg.Add(1)
+
+		go func(path string) {
+
+			defer wg.Done()
+
+			unlock := Lock(path)
+			defer unlock()
+
+			count++
+		}(paths[i%len(paths)])
+	}
+
+	wg.Wait()
+
+	if count != 100 {
+		t.Fatalf("Counted %v under the lock, expected 100.", count)
+	}
+}
@@ -0,0 +1,153 @@
+package logic
+
+import "strings"
+
+// A game with no fixed answer. Every guess gets the feedback
+// that keeps the most candidate answers alive, and the game
+// only commits to an answer once it is forced to.
+type AbsurdleGame struct {
+	Candidates  []string
+	Guesses     []string
+	Results     [][]int
+	UsedLetters map[rune]int
+}
+
+// Starts a new adversarial game with every answer word as a candidate.
+func NewAbsurdleGame() *AbsurdleGame {
+
+	candidates := make([]string, len(AnswerWords))
+	copy(candidates, AnswerWords)
+
+	return &AbsurdleGame{
+		Candidates:  candidates,
+		UsedLetters: make(map[rune]int),
+	}
+}
+
+// Attempt to make a guess with the given string.
+// If the guess string is invalid, an error is returned.
+func (a *AbsurdleGame) MakeGuess(guess string) error {
+
+	if err := validateGuess(guess, a.Guesses); err != nil {
+		return err
+	}
+
+	guessRunes := convertToRunes(guess)
+	groups := make(map[string][]string)
+	patterns := make(map[string][]int)
+
+	// Group the remaining candidates by the feedback they would give
+	for _, candidate := range a.Candidates {
+
+		result, err := scoreRunes(guessRunes, convertToRunes(candidate))
+
+		if err != nil {
+			return err
+		}
+
+		key := patternKey(result)
+		groups[key] = append(groups[key], candidate)
+		patterns[key] = result
+	}
+
+	best := ""
+
+	for key := range groups {
+
+		if best == "" || isWorseFeedback(groups[key], patterns[key], groups[best], patterns[best]) {
+			best = key
+		}
+	}
+
+	markUsedLetters(a.UsedLetters, guessRunes, patterns[best])
+	a.Candidates = groups[best]
+	a.Guesses = append(a.Guesses, guess)
+	a.Results = append(a.Results, patterns[best])
+
+	return nil
+}
+
+// Returns the answer the game has committed to, or
+// an empty string if more than one candidate remains.
+func (a *AbsurdleGame) Answer() string {
+
+	if len(a.Candidates) != 1 {
+		return ""
+	}
+
+	return a.Candidates[0]
+}
+
+// Returns true if the last guess was scored entirely correct.
+func (a *AbsurdleGame) HasWon() bool {
+
+	if len(a.Results) == 0 {
+		return false
+	}
+
+	return isSolvedResult(a.Results[len(a.Results)-1])
+}
+
+// Returns true if the feedback for the group of candidates a is worse for
+// the player than the feedback for the group b. Larger groups are always
+// worse, and ties go to the feedback that reveals the least.
+func isWorseFeedback(a []string, aResult []int, b []string, bResult []int) bool {
+
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+
+	aCorrect, aWrong := countHints(aResult)
+	bCorrect, bWrong := countHints(bResult)
+
+	if aCorrect != bCorrect {
+		return aCorrect < bCorrect
+	}
+
+	if aWrong != bWrong {
+		return aWrong < bWrong
+	}
+
+	// Keep the choice deterministic
+	return patternKey(aResult) < patternKey(bResult)
+}
+
+// Returns the number of correct and wrong position hints in the result.
+func countHints(result []int) (int, int) {
+
+	correct, wrong := 0, 0
+
+	for _, hint := range result {
+
+		switch hint {
+		case CorrectPosition:
+			correct++
+		case WrongPosition:
+			wrong++
+		}
+	}
+
+	return correct, wrong
+}
+
+// Returns true if every letter in the result is in the correct position.
+func isSolvedResult(result []int) bool {
+
+	correct, _ := countHints(result)
+
+	return len(result) > 0 && correct == len(result)
+}
+
+// Returns a string uniquely identifying the result,
+// suitable for use as a map key.
+func patternKey(result []int) string {
+
+	var key strings.Builder
+
+	for _, hint := range result {
+
+		key.WriteByte(byte('0' + hint))
+	}
+
+	return key.String()
+}
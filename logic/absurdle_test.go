@@ -0,0 +1,75 @@
+package logic
+
+import "testing"
+
+func TestAbsurdleMakeGuess(t *testing.T) {
+
+	game := NewAbsurdleGame()
+
+	if len(game.Candidates) != len(AnswerWords) {
+		t.Fatalf("NewAbsurdleGame() started with %v candidates, expected %v.", len(game.Candidates), len(AnswerWords))
+	}
+
+	// Invalid guess
+	if game.MakeGuess("aaaaa") == nil {
+		t.Fatal("MakeGuess(aaaaa) allowed an invalid word.")
+	}
+
+	guess := "crane"
+	before := len(game.Candidates)
+
+	if err := game.MakeGuess(guess); err != nil {
+		t.Fatalf("MakeGuess(%s) returned an error: %v", guess, err)
+	}
+
+	if len(game.Candidates) == 0 || len(game.Candidates) >= before {
+		t.Fatalf("MakeGuess(%s) left %v of %v candidates.", guess, len(game.Candidates), before)
+	}
+
+	// Every remaining candidate must agree with the feedback given
+	for _, candidate := range game.Candidates {
+
+		result, _ := scoreRunes(convertToRunes(guess), convertToRunes(candidate))
+
+		if !resultsEqual(result, game.Results[0]) {
+			t.Fatalf("Candidate %s gives %v, but feedback was %v.", candidate, result, game.Results[0])
+		}
+	}
+
+	if game.Answer() != "" {
+		t.Fatalf("Answer() committed to %s after one guess.", game.Answer())
+	}
+
+	if game.HasWon() {
+		t.Fatal("HasWon() returned true after one guess.")
+	}
+}
+
+func TestAbsurdleAvoidsCommitting(t *testing.T) {
+
+	game := NewAbsurdleGame()
+	game.Candidates = []string{"piety", "fjord"}
+
+	// Either word could be dodged, so the guess must miss
+	game.MakeGuess("piety")
+
+	if game.HasWon() || game.Answer() != "fjord" {
+		t.Fatalf("MakeGuess(piety) committed to the guess. Candidates: %v.", game.Candidates)
+	}
+
+	// Now the game is forced
+	game.MakeGuess("fjord")
+
+	if !game.HasWon() {
+		t.Fatalf("MakeGuess(fjord) did not win with candidates %v.", game.Candidates)
+	}
+}
+
+func TestPatternKey(t *testing.T) {
+
+	key := patternKey([]int{NotInWord, CorrectPosition, WrongPosition})
+
+	if key != "012" {
+		t.Fatalf("patternKey returned %s, expected 012.", key)
+	}
+}
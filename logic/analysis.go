@@ -0,0 +1,253 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+)
+
+// How much more information than expected a guess has to gain,
+// in bits, before it is counted as lucky.
+const LuckyBits = 1.0
+
+// How one guess of a finished game compares with the best guess
+// that could have been made at the same point.
+type GuessAnalysis struct {
+	Guess string
+	// The number of answers still possible before and after the guess.
+	Before int
+	After  int
+	// The information the guess actually gained, in bits.
+	Bits float64
+	// The information the guess was expected to gain, in bits,
+	// averaged over every answer still possible.
+	ExpectedBits float64
+	// The guess expected to gain the most information, and how much.
+	BestGuess string
+	BestBits  float64
+	// Whether the guess gained well over what it was expected to.
+	Lucky bool
+}
+
+// Goes over a finished game guess by guess, narrowing the answer words
+// down with each result in turn. An error is returned if a result
+// rules out every answer word, as happens with a custom answer.
+func Analyze(guesses []string, results [][]int) ([]GuessAnalysis, error) {
+
+	if len(guesses) != len(results) {
+		return nil, errors.New("every guess needs a result")
+	}
+
+	candidates := AnswerWords
+	analysis := make([]GuessAnalysis, len(guesses))
+
+	for i, guess := range guesses {
+
+		remaining := filterByPattern(candidates, guess, EncodePattern(results[i]))
+
+		if len(remaining) == 0 {
+			return nil, errors.New("no answer word fits the results")
+		}
+
+		bits := math.Log2(float64(len(candidates)) / float64(len(remaining)))
+		expected := patternEntropy(guess, candidates)
+		best := RankGuesses(candidates, 1)[0]
+
+		analysis[i] = GuessAnalysis{
+			Guess:        guess,
+			Before:       len(candidates),
+			After:        len(remaining),
+			Bits:         bits,
+			ExpectedBits: expected,
+			BestGuess:    best.Word,
+			BestBits:     best.Bits,
+			Lucky:        bits >= expected+LuckyBits,
+		}
+
+		candidates = remaining
+	}
+
+	return analysis, nil
+}
+
+// Returns the answer words that would have given every result for its
+// guess, scoring each one just as a game would.
+func Candidates(guesses []string, results [][]int) ([]string, error) {
+
+	if len(guesses) != len(results) {
+		return nil, errors.New("every guess needs a result")
+	}
+
+	candidates := AnswerWords
+
+	for i, guess := range guesses {
+		candidates = filterByPattern(candidates, guess, EncodePattern(results[i]))
+	}
+
+	return candidates, nil
+}
+
+// Returns the candidates that would have given the pattern for the guess.
+func filterByPattern(candidates []string, guess string, pattern Pattern) []string {
+
+	var remaining []string
+
+	for _, candidate := range candidates {
+
+		if ScorePattern(guess, candidate) == pattern {
+			remaining = append(remaining, candidate)
+		}
+	}
+
+	return remaining
+}
+
+// A possible guess and the information it is expected to gain, in bits.
+type RankedGuess struct {
+	Word string
+	Bits float64
+}
+
+// Returns the valid words expected to gain the most information against
+// the candidates, best first, keeping at most limit of them. Ties go to
+// words that could be the answer, then to the word first in the list.
+func RankGuesses(candidates []string, limit int) []RankedGuess {
+
+	return rankGuesses(context.Background(), candidates, limit, nil)
+}
+
+// Ranks guesses as RankGuesses does, leaving out the words allow
+// returns false for. Every word is ranked if allow is nil. Once the
+// context is done the words not yet scored are ranked as gaining nothing.
+func rankGuesses(ctx context.Context, candidates []string, limit int, allow func(word string) bool) []RankedGuess {
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	m, columns := candidateMatrix(candidates)
+	bits := make([]float64, len(m.Guesses))
+
+	parallelForContext(ctx, len(m.Guesses), func(g int) {
+
+		sizes := m.groupSizes(g, columns)
+		bits[g] = entropy(&sizes, len(columns))
+	})
+
+	isCandidate := wordSet(candidates)
+
+	better := func(a, b int) bool {
+
+		// Treat expectations this close as a tie, since they
+		// may differ only by floating point rounding
+		if math.Abs(bits[a]-bits[b]) > 1e-9 {
+			return bits[a] > bits[b]
+		}
+
+		if first, decided := candidateFirst(isCandidate, m.Guesses[a], m.Guesses[b]); decided {
+			return first
+		}
+
+		return a < b
+	}
+
+	if limit > len(m.Guesses) {
+		limit = len(m.Guesses)
+	}
+
+	// Pick out the best few directly rather than sorting every word
+	var order []int
+
+	for g := range m.Guesses {
+
+		if allow != nil && !allow(m.Guesses[g]) {
+			continue
+		}
+
+		if len(order) == limit && !better(g, order[limit-1]) {
+			continue
+		}
+
+		i := sort.Search(len(order), func(i int) bool { return better(g, order[i]) })
+
+		if len(order) < limit {
+			order = append(order, 0)
+		}
+
+		copy(order[i+1:], order[i:])
+		order[i] = g
+	}
+
+	ranked := make([]RankedGuess, len(order))
+
+	for i, g := range order {
+		ranked[i] = RankedGuess{Word: m.Guesses[g], Bits: bits[g]}
+	}
+
+	return ranked
+}
+
+// Returns a matrix of every valid word against the candidates, and the
+// columns of the candidates in it. The default matrix is used when every
+// candidate is an answer word, otherwise one is built for the candidates.
+func candidateMatrix(candidates []string) (*PatternMatrix, []int) {
+
+	m := DefaultMatrix()
+	columns := make([]int, len(candidates))
+
+	for i, candidate := range candidates {
+
+		a, ok := m.AnswerIndex(candidate)
+
+		if !ok {
+			return NewPatternMatrix(ValidWords, candidates), indexes(len(candidates))
+		}
+
+		columns[i] = a
+	}
+
+	return m, columns
+}
+
+// Returns the numbers from zero up to n.
+func indexes(n int) []int {
+
+	numbers := make([]int, n)
+
+	for i := range numbers {
+		numbers[i] = i
+	}
+
+	return numbers
+}
+
+// Returns the information the guess is expected to gain against the
+// candidates, in bits: the entropy of the patterns it could get.
+func patternEntropy(guess string, candidates []string) float64 {
+
+	var sizes [PatternCount]int
+
+	for _, candidate := range candidates {
+		sizes[ScorePattern(guess, candidate)]++
+	}
+
+	return entropy(&sizes, len(candidates))
+}
+
+// Returns the entropy, in bits, of the group sizes out of the total.
+func entropy(sizes *[PatternCount]int, total int) float64 {
+
+	bits := 0.0
+
+	for _, size := range sizes {
+
+		if size > 0 {
+
+			p := float64(size) / float64(total)
+			bits -= p * math.Log2(p)
+		}
+	}
+
+	return bits
+}
@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+
+	game := CreateGame("would")
+
+	for _, guess := range []string{"crane", "sloth", "would"} {
+		game.MakeGuess(guess)
+	}
+
+	analysis, err := Analyze(game.Guesses, game.Results)
+
+	if err != nil {
+		t.Fatalf("Analyze returned an error: %v", err)
+	}
+
+	if len(analysis) != 3 {
+		t.Fatalf("Analyze returned %v guesses, expected 3.", len(analysis))
+	}
+
+	first := analysis[0]
+
+	if first.Before != len(AnswerWords) || first.After != analysis[1].Before {
+		t.Fatalf("First guess narrowed %v candidates to %v, then the second started from %v.", first.Before, first.After, analysis[1].Before)
+	}
+
+	if math.Abs(first.Bits-math.Log2(float64(first.Before)/float64(first.After))) > 1e-9 {
+		t.Fatalf("First guess gained %v bits going from %v to %v candidates.", first.Bits, first.Before, first.After)
+	}
+
+	for _, a := range analysis {
+
+		if a.BestBits < a.ExpectedBits-1e-9 {
+			t.Fatalf("Guess %s was expected to gain %v bits, more than the best guess %s with %v.", a.Guess, a.ExpectedBits, a.BestGuess, a.BestBits)
+		}
+	}
+
+	last := analysis[2]
+
+	if last.Before != 1 || last.After != 1 || last.BestGuess != "would" || last.Lucky {
+		t.Fatalf("Solving guess was analyzed as %+v.", last)
+	}
+}
+
+func TestAnalyzeLucky(t *testing.T) {
+
+	// Solving on the first guess gains every bit there is
+	game := CreateGame("would")
+	game.MakeGuess("would")
+
+	analysis, err := Analyze(game.Guesses, game.Results)
+
+	if err != nil {
+		t.Fatalf("Analyze returned an error: %v", err)
+	}
+
+	if !analysis[0].Lucky || analysis[0].After != 1 {
+		t.Fatalf("Solving on the first guess was analyzed as %+v.", analysis[0])
+	}
+}
+
+func TestAnalyzeUnknownAnswer(t *testing.T) {
+
+	game := CreateGame("piety")
+	game.MakeGuess("piety")
+
+	if _, err := Analyze(game.Guesses, game.Results); err == nil {
+		t.Fatalf("Analyze did not return an error for an answer outside the answer words.")
+	}
+}
+
+func TestCandidates(t *testing.T) {
+
+	game := CreateGame("would")
+	game.MakeGuess("crane")
+	game.MakeGuess("sloth")
+
+	candidates, err := Candidates(game.Guesses, game.Results)
+
+	if err != nil || len(candidates) != 1 || candidates[0] != "would" {
+		t.Fatalf("Candidates returned %v, %v.", candidates, err)
+	}
+}
+
+func TestRankGuesses(t *testing.T) {
+
+	ranked := RankGuesses([]string{"would", "could", "mould"}, 3)
+
+	if len(ranked) != 3 {
+		t.Fatalf("RankGuesses returned %v guesses, expected 3.", len(ranked))
+	}
+
+	// Splitting all three apart is the most that can be gained
+	if math.Abs(ranked[0].Bits-math.Log2(3)) > 1e-9 {
+		t.Fatalf("RankGuesses ranked %s first with %v bits.", ranked[0].Word, ranked[0].Bits)
+	}
+
+	for i := 1; i < len(ranked); i++ {
+
+		if ranked[i].Bits > ranked[i-1].Bits {
+			t.Fatalf("RankGuesses returned %v out of order.", ranked)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package logic
+
+import "sync"
+
+// The kinds of events a game emits.
+type EventType int
+
+const (
+	GameStarted EventType = iota
+	GuessAccepted
+	GuessRejected
+	GameWon
+	GameLost
+)
+
+var eventNames = map[EventType]string{
+	GameStarted:   "game started",
+	GuessAccepted: "guess accepted",
+	GuessRejected: "guess rejected",
+	GameWon:       "game won",
+	GameLost:      "game lost",
+}
+
+func (t EventType) String() string {
+
+	return eventNames[t]
+}
+
+// Something that happened in a game.
+type Event struct {
+	Type EventType
+	// The game the event happened in.
+	Game *Game
+	// The guess, for every event about a guess.
+	Guess string
+	// The result of an accepted guess.
+	Result []int
+	// Why a guess was rejected.
+	Err error
+}
+
+// Called with every event emitted to the subscriptions it was given to.
+type Observer func(Event)
+
+// A set of subscriptions that games emit their events to. Any number
+// of games can share one, and it is safe to subscribe, unsubscribe
+// and emit from several goroutines at once.
+type Events struct {
+	mu        sync.RWMutex
+	next      int
+	observers map[int]Observer
+}
+
+// Creates an empty set of subscriptions.
+func NewEvents() *Events {
+
+	return &Events{observers: make(map[int]Observer)}
+}
+
+// Calls the observer with every event emitted from now on,
+// until the returned function is called to unsubscribe.
+func (e *Events) Subscribe(observer Observer) (unsubscribe func()) {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	id := e.next
+	e.next++
+	e.observers[id] = observer
+
+	return func() {
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		delete(e.observers, id)
+	}
+}
+
+// Calls every subscribed observer with the event. The observers are
+// called outside the lock, so they may subscribe or unsubscribe.
+// Emitting to nil subscriptions does nothing.
+func (e *Events) Emit(event Event) {
+
+	if e == nil {
+		return
+	}
+
+	e.mu.RLock()
+	observers := make([]Observer, 0, len(e.observers))
+
+	for _, observer := range e.observers {
+		observers = append(observers, observer)
+	}
+
+	e.mu.RUnlock()
+
+	for _, observer := range observers {
+		observer(event)
+	}
+}
@@ -0,0 +1,102 @@
+package logic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGameEvents(t *testing.T) {
+
+	events := NewEvents()
+
+	var seen []EventType
+	unsubscribe := events.Subscribe(func(e Event) { seen = append(seen, e.Type) })
+
+	game := CreateObservedGame("piety", events)
+	game.MakeGuess("aaaaa")
+	game.MakeGuess("crane")
+	game.MakeGuess("piety")
+
+	expected := []EventType{GameStarted, GuessRejected, GuessAccepted, GuessAccepted, GameWon}
+
+	if len(seen) != len(expected) {
+		t.Fatalf("Observer saw %v, but expected %v.", seen, expected)
+	}
+
+	for i := range expected {
+
+		if seen[i] != expected[i] {
+			t.Fatalf("Observer saw %v, but expected %v.", seen, expected)
+		}
+	}
+
+	unsubscribe()
+	CreateObservedGame("piety", events)
+
+	if len(seen) != len(expected) {
+		t.Fatalf("Observer was called after unsubscribing: %v.", seen)
+	}
+}
+
+func TestGameLostEvent(t *testing.T) {
+
+	events := NewEvents()
+	lost := 0
+	events.Subscribe(func(e Event) {
+
+		if e.Type == GameLost {
+			lost++
+		}
+	})
+
+	game := CreateObservedGame("piety", events)
+
+	for _, guess := range []string{"crane", "slate", "audio", "mount", "light", "brick"} {
+		game.MakeGuess(guess)
+	}
+
+	if lost != 1 || !game.IsOver() {
+		t.Fatalf("GameLost was emitted %v times after %v guesses.", lost, len(game.Guesses))
+	}
+}
+
+func TestConcurrentEvents(t *testing.T) {
+
+	events := NewEvents()
+
+	var mu sync.Mutex
+	won := 0
+
+	events.Subscribe(func(e Event) {
+
+		if e.Type == GameWon {
+
+			mu.Lock()
+			won++
+			mu.Unlock()
+		}
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+
+		wg.Add(1)
+		go func() {
+
+			defer wg.Done()
+
+			unsubscribe := events.Subscribe(func(Event) {})
+			defer unsubscribe()
+
+			game := CreateObservedGame("piety", events)
+			game.MakeGuess("piety")
+		}()
+	}
+
+	wg.Wait()
+
+	if won != 20 {
+		t.Fatalf("GameWon was emitted %v times, but expected 20.", won)
+	}
+}
@@ -0,0 +1,152 @@
+package logic
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+)
+
+// Fibble gives more guesses to make up for the lies.
+const FibbleMaxGuesses int = 9
+
+// A game in which exactly one tile of every row of feedback lies.
+// The true results are kept apart from the ones shown to the player
+// so the lies can be revealed once the game is over.
+type FibbleGame struct {
+	Answer    string
+	Guesses   []string
+	Results   [][]int
+	Displayed [][]int
+	// The position of the lying tile in each row.
+	Lies []int
+	// Built from the displayed results, so it may be lied to as well.
+	UsedLetters map[rune]int
+	// The number of hints taken.
+	Hints int
+}
+
+// Starts a new lying game.
+func NewFibbleGame() *FibbleGame {
+
+	return &FibbleGame{
+		Answer:      selectWord(),
+		UsedLetters: make(map[rune]int),
+	}
+}
+
+// Attempt to make a guess with the given string.
+// If the guess string is invalid, an error is returned.
+func (f *FibbleGame) MakeGuess(guess string) error {
+
+	if err := validateGuess(guess, f.Guesses); err != nil {
+		return err
+	}
+
+	guessRunes := convertToRunes(guess)
+	result, err := scoreRunes(guessRunes, convertToRunes(f.Answer))
+
+	if err != nil {
+		return err
+	}
+
+	lie := rand.Intn(len(result))
+	displayed := make([]int, len(result))
+	copy(displayed, result)
+	displayed[lie] = lieAbout(result[lie])
+
+	markUsedLetters(f.UsedLetters, guessRunes, displayed)
+	f.Guesses = append(f.Guesses, guess)
+	f.Results = append(f.Results, result)
+	f.Displayed = append(f.Displayed, displayed)
+	f.Lies = append(f.Lies, lie)
+
+	return nil
+}
+
+// Returns true if the last guess made was the answer.
+func (f *FibbleGame) HasWon() bool {
+
+	if len(f.Guesses) == 0 {
+		return false
+	}
+
+	return strings.EqualFold(f.Guesses[len(f.Guesses)-1], f.Answer)
+}
+
+// Returns true if the game has been won or all guesses are used up.
+func (f *FibbleGame) IsOver() bool {
+
+	return f.HasWon() || len(f.Guesses) >= FibbleMaxGuesses
+}
+
+// Returns the answer words not yet guessed that could have given every
+// displayed result, which means each result is wrong about exactly
+// one of the word's letters.
+func (f *FibbleGame) Remaining() []string {
+
+	var remaining []string
+
+	for _, candidate := range AnswerWords {
+
+		if !containsWord(f.Guesses, candidate) && fitsLies(f.Guesses, f.Displayed, candidate) {
+			remaining = append(remaining, candidate)
+		}
+	}
+
+	return remaining
+}
+
+// Takes a hint suggesting the next guess chosen by the strategy from
+// the answers still possible despite the lies. An error is returned
+// if no answer word fits the results so far.
+func (f *FibbleGame) SuggestGuess(strategy Strategy) (string, error) {
+
+	remaining := f.Remaining()
+
+	if len(remaining) == 0 {
+		return "", errors.New("no answer word fits the results")
+	}
+
+	f.Hints++
+
+	return strategy.NextGuess(SolverState{Guesses: f.Guesses, Results: f.Displayed, Candidates: remaining}), nil
+}
+
+// Returns true if each displayed result differs in exactly one position
+// from the result its guess would have got against the answer.
+func fitsLies(guesses []string, displayed [][]int, answer string) bool {
+
+	for i, guess := range guesses {
+
+		truth := ScorePattern(guess, answer).Result()
+		differences := 0
+
+		for j, hint := range displayed[i] {
+
+			if truth[j] != hint {
+				differences++
+			}
+		}
+
+		if differences != 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns a randomly chosen hint other than the true one.
+func lieAbout(hint int) int {
+
+	var lies []int
+
+	for _, h := range []int{NotInWord, CorrectPosition, WrongPosition} {
+
+		if h != hint {
+			lies = append(lies, h)
+		}
+	}
+
+	return lies[rand.Intn(len(lies))]
+}
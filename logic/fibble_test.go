@@ -0,0 +1,110 @@
+package logic
+
+import "testing"
+
+func TestFibbleMakeGuess(t *testing.T) {
+
+	game := NewFibbleGame()
+
+	if !isValidAnswerWord(game.Answer) {
+		t.Fatalf("NewFibbleGame() chose %s, which is not an answer word.", game.Answer)
+	}
+
+	game.Answer = "piety"
+
+	// Invalid guess
+	if game.MakeGuess("aaaaa") == nil {
+		t.Fatal("MakeGuess(aaaaa) allowed an invalid word.")
+	}
+
+	for _, guess := range []string{"crane", "fjord", "piety"} {
+
+		if err := game.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%s) returned an error: %v", guess, err)
+		}
+	}
+
+	for i, guess := range game.Guesses {
+
+		expected, _ := scoreRunes(convertToRunes(guess), convertToRunes(game.Answer))
+
+		if !resultsEqual(game.Results[i], expected) {
+			t.Fatalf("MakeGuess(%s) recorded true result %v, expected %v.", guess, game.Results[i], expected)
+		}
+
+		// Exactly one tile lies, and it is the recorded one
+		lies := 0
+
+		for j := range expected {
+
+			if game.Displayed[i][j] != expected[j] {
+
+				lies++
+
+				if j != game.Lies[i] {
+					t.Fatalf("MakeGuess(%s) lied at %v, but recorded the lie at %v.", guess, j, game.Lies[i])
+				}
+			}
+		}
+
+		if lies != 1 {
+			t.Fatalf("MakeGuess(%s) displayed %v with %v lies, expected exactly 1.", guess, game.Displayed[i], lies)
+		}
+	}
+
+	if !game.HasWon() || !game.IsOver() {
+		t.Fatal("HasWon() returned false after guessing the answer.")
+	}
+}
+
+func TestLieAbout(t *testing.T) {
+
+	for _, hint := range []int{NotInWord, CorrectPosition, WrongPosition} {
+
+		for i := 0; i < 20; i++ {
+
+			if lie := lieAbout(hint); lie == hint {
+				t.Fatalf("lieAbout(%v) told the truth.", hint)
+			}
+		}
+	}
+}
+
+func TestFibbleRemaining(t *testing.T) {
+
+	game := NewFibbleGame()
+	game.Answer = "would"
+
+	for _, guess := range []string{"crane", "fjord", "sloth"} {
+		game.MakeGuess(guess)
+	}
+
+	remaining := game.Remaining()
+
+	// The lies can't hide the answer, and words already guessed are out
+	if !containsWord(remaining, "would") || containsWord(remaining, "crane") || len(remaining) >= len(AnswerWords) {
+		t.Fatalf("Remaining() returned %v answers, including would: %v.", len(remaining), containsWord(remaining, "would"))
+	}
+
+	word, err := game.SuggestGuess(Strategies["first"])
+
+	if err != nil || !containsWord(remaining, word) || game.Hints != 1 {
+		t.Fatalf("SuggestGuess() returned %s, %v after %v hints.", word, err, game.Hints)
+	}
+}
+
+func TestFitsLies(t *testing.T) {
+
+	truth := ScorePattern("crane", "piety").Result()
+	lied := append([]int(nil), truth...)
+	lied[0] = CorrectPosition
+
+	if !fitsLies([]string{"crane"}, [][]int{lied}, "piety") {
+		t.Fatalf("fitsLies rejected piety for %v, which lies once.", FormatPattern(lied))
+	}
+
+	// A row telling the whole truth can't have come from the answer
+	if fitsLies([]string{"crane"}, [][]int{truth}, "piety") {
+		t.Fatalf("fitsLies accepted piety for %v, which doesn't lie.", FormatPattern(truth))
+	}
+}
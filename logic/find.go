@@ -0,0 +1,187 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The orders words found by a query can be sorted in.
+const (
+	// Alphabetical order.
+	SortAlphabetical = "alpha"
+	// Words made of the most common letters first.
+	SortFrequency = "frequency"
+	// Answer words first, each group in alphabetical order.
+	SortAnswers = "answer"
+)
+
+// A search of the dictionaries. Fields left at their zero value
+// match every word.
+type Query struct {
+	// The letters of the word, with '?' matching any letter, e.g. "?r?ne".
+	Pattern string
+	// Letters the word must contain, as many times as they are given.
+	Required string
+	// Letters the word must not contain.
+	Excluded string
+	// Letters the word must not have at each position, counting from zero.
+	NotAt map[int]string
+	// Only search the answer words.
+	AnswersOnly bool
+	// How to order the words found. Alphabetical if empty.
+	Sort string
+}
+
+// Returns the words matching the query, in the order it asks for. An
+// error is returned if the query can't match any word of the right length.
+func Find(q Query) ([]string, error) {
+
+	if q.Pattern != "" && len(q.Pattern) != WordLength {
+		return nil, fmt.Errorf("pattern must be %v letters long", WordLength)
+	}
+
+	for position := range q.NotAt {
+
+		if position < 0 || position >= WordLength {
+			return nil, fmt.Errorf("position %v is outside the word", position+1)
+		}
+	}
+
+	words := ValidWords
+
+	if q.AnswersOnly {
+		words = AnswerWords
+	}
+
+	var found []string
+
+	for _, word := range words {
+
+		if q.matches(word) {
+			found = append(found, word)
+		}
+	}
+
+	switch q.Sort {
+
+	case "", SortAlphabetical:
+		sort.Strings(found)
+
+	case SortFrequency:
+		SortByFrequency(found)
+
+	case SortAnswers:
+		answers := AnswerSet()
+
+		sort.SliceStable(found, func(i, j int) bool {
+
+			iAnswer, jAnswer := answers[strings.ToLower(found[i])], answers[strings.ToLower(found[j])]
+
+			if iAnswer != jAnswer {
+				return iAnswer
+			}
+
+			return found[i] < found[j]
+		})
+
+	default:
+		return nil, errors.New("unknown sort order " + q.Sort)
+	}
+
+	return found, nil
+}
+
+// Returns true if the word matches every part of the query.
+func (q Query) matches(word string) bool {
+
+	word = strings.ToLower(word)
+
+	for i, c := range strings.ToLower(q.Pattern) {
+
+		if c != '?' && rune(word[i]) != c {
+			return false
+		}
+	}
+
+	for c, count := range letterCounts(strings.ToLower(q.Required)) {
+
+		if strings.Count(word, string(c)) < count {
+			return false
+		}
+	}
+
+	if strings.ContainsAny(word, strings.ToLower(q.Excluded)) {
+		return false
+	}
+
+	for position, letters := range q.NotAt {
+
+		if strings.ContainsRune(strings.ToLower(letters), rune(word[position])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the number of times each letter appears in the text.
+func letterCounts(text string) map[rune]int {
+
+	counts := make(map[rune]int)
+
+	for _, c := range text {
+		counts[c]++
+	}
+
+	return counts
+}
+
+// Returns a set of the answer words in lower case, for checking many words against.
+func AnswerSet() map[string]bool {
+
+	return wordSet(AnswerWords)
+}
+
+// Sorts the words so those made of the letters most common in the
+// answer words come first. Each distinct letter counts once, so
+// repeated letters don't lift a word. Ties are alphabetical.
+func SortByFrequency(words []string) {
+
+	frequency := letterCounts(strings.Join(AnswerWords, ""))
+	scores := make(map[string]int, len(words))
+
+	for _, word := range words {
+
+		for c := range letterCounts(strings.ToLower(word)) {
+			scores[word] += frequency[c]
+		}
+	}
+
+	sort.SliceStable(words, func(i, j int) bool {
+
+		if scores[words[i]] != scores[words[j]] {
+			return scores[words[i]] > scores[words[j]]
+		}
+
+		return words[i] < words[j]
+	})
+}
+
+// Returns the valid words that would have been given the result
+// against the answer, in the order of the valid words.
+func GuessesGiving(answer string, result []int) []string {
+
+	pattern := EncodePattern(result)
+	var guesses []string
+
+	for _, guess := range ValidWords {
+
+		if ScorePattern(guess, answer) == pattern {
+			guesses = append(guesses, guess)
+		}
+	}
+
+	return guesses
+}
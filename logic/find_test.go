@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+
+	tests := []struct {
+		query    Query
+		expected string
+	}{
+		{Query{Pattern: "?r?ne", Excluded: "bcdgkptu"}, "irene irone"},
+		{Query{Pattern: "cr??e", AnswersOnly: true}, "crime"},
+		{Query{Pattern: "??ree", Required: "pu"}, "puree"},
+		{Query{Pattern: "s?ree", NotAt: map[int]string{1: "chiopt"}}, "saree sfree"},
+	}
+
+	for _, test := range tests {
+
+		found, err := Find(test.query)
+
+		if err != nil {
+			t.Fatalf("Find(%+v) returned an error: %v", test.query, err)
+		}
+
+		if strings.Join(found, " ") != test.expected {
+			t.Fatalf("Find(%+v) returned %v, expected %s.", test.query, found, test.expected)
+		}
+	}
+
+	if _, err := Find(Query{Pattern: "????"}); err == nil {
+		t.Fatalf("Find accepted a pattern of the wrong length.")
+	}
+}
+
+func TestFindSort(t *testing.T) {
+
+	found, err := Find(Query{Pattern: "cr??e", Sort: SortAnswers})
+
+	if err != nil || len(found) < 2 || found[0] != "crime" {
+		t.Fatalf("Find did not put answer words first: %v, %v.", found, err)
+	}
+
+	words := []string{"fuzzy", "arise", "jazzy"}
+	SortByFrequency(words)
+
+	if words[0] != "arise" || words[2] != "fuzzy" {
+		t.Fatalf("SortByFrequency returned %v.", words)
+	}
+}
+
+func TestGuessesGiving(t *testing.T) {
+
+	guesses := GuessesGiving("crane", []int{CorrectPosition, CorrectPosition, NotInWord, CorrectPosition, CorrectPosition})
+
+	if strings.Join(guesses, " ") != "crine crone" {
+		t.Fatalf("GuessesGiving returned %v, expected crine crone.", guesses)
+	}
+
+	// Four letters in place leave no room for the fifth to be elsewhere
+	if guesses := GuessesGiving("crane", []int{CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition, WrongPosition}); len(guesses) != 0 {
+		t.Fatalf("GuessesGiving returned %v for an impossible row.", guesses)
+	}
+}
+
+func TestAnswerSet(t *testing.T) {
+
+	answers := AnswerSet()
+
+	if len(answers) != len(AnswerWords) || !answers["bible"] || answers["crane"] {
+		t.Fatalf("AnswerSet returned %v answers, bible: %v, crane: %v.", len(answers), answers["bible"], answers["crane"])
+	}
+}
@@ -0,0 +1,201 @@
+package logic
+
+import (
+	"errors"
+	"strings"
+)
+
+// A game against a single answer. Unlike the package level
+// game state, any number of Games can be in progress at once.
+type Game struct {
+	Answer      string
+	Guesses     []string
+	Results     [][]int
+	UsedLetters map[rune]int
+	// The number of guesses before the game is lost,
+	// or zero if there is no limit.
+	MaxGuesses int
+	// Where the game's events are emitted, if anywhere.
+	Events *Events
+	// The number of hints taken.
+	Hints int
+	// Whether the player gave up before the game was over.
+	GaveUp bool
+	// Whether the game's time ran out before it was over.
+	TimedOut bool
+
+	// The positions of the answer given away by hints.
+	revealed [WordLength]bool
+}
+
+// Creates a new game with the given answer.
+func CreateGame(answer string) *Game {
+
+	return &Game{
+		Answer:      answer,
+		UsedLetters: make(map[rune]int),
+		MaxGuesses:  MaxGuesses,
+	}
+}
+
+// Creates a new game with the given answer that emits its events,
+// starting with GameStarted, to the given subscriptions.
+func CreateObservedGame(answer string, events *Events) *Game {
+
+	game := CreateGame(answer)
+	game.Events = events
+	events.Emit(Event{Type: GameStarted, Game: game})
+
+	return game
+}
+
+// Creates an observed game with an answer chosen by a player rather
+// than from the answer words. An error is returned if the answer
+// is not a valid word.
+func CreateGameWithAnswer(answer string, events *Events) (*Game, error) {
+
+	if err := ValidateWord(answer); err != nil {
+		return nil, err
+	}
+
+	return CreateObservedGame(strings.ToLower(answer), events), nil
+}
+
+// Attempt to make a guess with the given string.
+// If the guess string is invalid, an error is returned.
+func (g *Game) MakeGuess(guess string) error {
+
+	if err := validateGuess(guess, g.Guesses); err != nil {
+
+		g.Events.Emit(Event{Type: GuessRejected, Game: g, Guess: guess, Err: err})
+		return err
+	}
+
+	guessRunes := convertToRunes(guess)
+	result, err := scoreRunes(guessRunes, convertToRunes(g.Answer))
+
+	if err != nil {
+
+		g.Events.Emit(Event{Type: GuessRejected, Game: g, Guess: guess, Err: err})
+		return err
+	}
+
+	markUsedLetters(g.UsedLetters, guessRunes, result)
+	g.Guesses = append(g.Guesses, guess)
+	g.Results = append(g.Results, result)
+
+	g.Events.Emit(Event{Type: GuessAccepted, Game: g, Guess: guess, Result: result})
+
+	switch {
+	case g.HasWon():
+		g.Events.Emit(Event{Type: GameWon, Game: g, Guess: guess, Result: result})
+	case g.IsOver():
+		g.Events.Emit(Event{Type: GameLost, Game: g, Guess: guess, Result: result})
+	}
+
+	return nil
+}
+
+// Returns true if the last guess made was the answer.
+func (g *Game) HasWon() bool {
+
+	if len(g.Guesses) == 0 {
+		return false
+	}
+
+	return strings.EqualFold(g.Guesses[len(g.Guesses)-1], g.Answer)
+}
+
+// Returns true if the game has been won, given up or timed out, or all guesses are used up.
+func (g *Game) IsOver() bool {
+
+	return g.HasWon() || g.GaveUp || g.TimedOut || (g.MaxGuesses > 0 && len(g.Guesses) >= g.MaxGuesses)
+}
+
+// Ends the game as a loss, unless it is already over.
+func (g *Game) GiveUp() {
+
+	if g.IsOver() {
+		return
+	}
+
+	g.GaveUp = true
+	g.Events.Emit(Event{Type: GameLost, Game: g})
+}
+
+// Ends the game as a loss because its time ran out, unless it is already over.
+func (g *Game) TimeOut() {
+
+	if g.IsOver() {
+		return
+	}
+
+	g.TimedOut = true
+	g.Events.Emit(Event{Type: GameLost, Game: g})
+}
+
+// Returns everything the results so far have revealed about the answer.
+func (g *Game) Knowledge() *Knowledge {
+
+	k, _ := BuildKnowledge(g.Guesses, g.Results)
+
+	return k
+}
+
+// Returns the answer words that fit every result so far.
+func (g *Game) Remaining() []string {
+
+	k := g.Knowledge()
+	var remaining []string
+
+	for _, candidate := range AnswerWords {
+
+		if k.Allows(candidate) {
+			remaining = append(remaining, candidate)
+		}
+	}
+
+	return remaining
+}
+
+// Takes a hint revealing the letter in the first position not yet
+// found or revealed. An error is returned if every letter is known.
+func (g *Game) RevealLetter() (int, rune, error) {
+
+	fixed := g.Knowledge().Fixed
+	answer := convertToRunes(g.Answer)
+
+	for i := range answer {
+
+		if fixed[i] == 0 && !g.revealed[i] {
+
+			g.revealed[i] = true
+			g.Hints++
+			return i, answer[i], nil
+		}
+	}
+
+	return 0, 0, errors.New("every letter is already known")
+}
+
+// Takes a hint suggesting an answer word, not yet guessed, that would
+// have given every result so far. An error is returned if there is none.
+func (g *Game) SuggestWord() (string, error) {
+
+	return g.SuggestGuess(Strategies["first"])
+}
+
+// Takes a hint suggesting the next guess chosen by the strategy.
+// An error is returned if no answer word fits the results so far.
+func (g *Game) SuggestGuess(strategy Strategy) (string, error) {
+
+	remaining := g.Remaining()
+
+	if len(remaining) == 0 {
+		return "", errors.New("no answer word fits the results")
+	}
+
+	g.Hints++
+
+	return strategy.NextGuess(SolverState{Guesses: g.Guesses, Results: g.Results, Candidates: remaining}), nil
+}
@@ -0,0 +1,188 @@
+package logic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGameMakeGuess(t *testing.T) {
+
+	game := CreateGame("vilag")
+
+	// Valid guess
+	guess := "valid"
+	expectedResult := []int{1, 2, 1, 2, 0}
+	err := game.MakeGuess(guess)
+
+	if err != nil {
+		t.Fatalf("MakeGuess(%s) returned an error for valid word.", guess)
+	}
+
+	if len(game.Results) != 1 || !resultsEqual(game.Results[0], expectedResult) {
+		t.Fatalf("MakeGuess(%s) produced results %v, but expected %v.", guess, game.Results, expectedResult)
+	}
+
+	if game.UsedLetters['I'] != WrongPosition {
+		t.Fatalf("MakeGuess(%s) did not mark used letters. Actual: %v.", guess, game.UsedLetters)
+	}
+
+	// Duplicate guess
+	if game.MakeGuess(guess) == nil {
+		t.Fatalf("MakeGuess(%s) allowed duplicate word.", guess)
+	}
+
+	// Invalid guess
+	guess = "aaaaa"
+	if game.MakeGuess(guess) == nil {
+		t.Fatalf("MakeGuess(%s) allowed invalid word.", guess)
+	}
+
+	if len(game.Guesses) != 1 {
+		t.Fatalf("MakeGuess added invalid guesses. Guesses: %v.", game.Guesses)
+	}
+}
+
+func TestGameDoesNotTouchGlobals(t *testing.T) {
+
+	NewGame()
+	game := CreateGame("vilag")
+	game.MakeGuess("valid")
+
+	if len(Guesses) != 0 || len(UsedLetters) != 0 {
+		t.Fatalf("Game.MakeGuess changed the package level game. Guesses: %v, UsedLetters: %v.", Guesses, UsedLetters)
+	}
+}
+
+func TestGameHasWon(t *testing.T) {
+
+	game := CreateGame("Asian")
+
+	if game.HasWon() {
+		t.Fatal("HasWon() returned true before any guesses were made.")
+	}
+
+	game.MakeGuess("piety")
+
+	if game.HasWon() {
+		t.Fatal("HasWon() returned true after an incorrect guess.")
+	}
+
+	game.MakeGuess("asian")
+
+	if !game.HasWon() {
+		t.Fatal("HasWon() returned false after guessing the answer in a different case.")
+	}
+}
+
+func TestGameHints(t *testing.T) {
+
+	game := CreateGame("would")
+	game.MakeGuess("could")
+
+	position, letter, err := game.RevealLetter()
+
+	if err != nil || position != 0 || letter != 'W' {
+		t.Fatalf("RevealLetter() returned %v, %c, %v, expected 0, W.", position, letter, err)
+	}
+
+	remaining := game.Remaining()
+
+	if !containsWord(remaining, "would") || containsWord(remaining, "could") {
+		t.Fatalf("Remaining() returned %v.", remaining)
+	}
+
+	word, err := game.SuggestWord()
+
+	if err != nil || !containsWord(remaining, word) {
+		t.Fatalf("SuggestWord() returned %s, %v, expected one of %v.", word, err, remaining)
+	}
+
+	if game.Hints != 2 {
+		t.Fatalf("Taking two hints counted %v.", game.Hints)
+	}
+
+	game.MakeGuess("would")
+
+	if _, _, err := game.RevealLetter(); err == nil {
+		t.Fatalf("RevealLetter() gave a hint after every letter was found.")
+	}
+}
+
+func TestGameRevealLetterTwice(t *testing.T) {
+
+	game := CreateGame("piety")
+	game.MakeGuess("crane")
+
+	first, _, err := game.RevealLetter()
+
+	if err != nil || first != 0 {
+		t.Fatalf("RevealLetter() returned %v, %v, expected 0.", first, err)
+	}
+
+	second, letter, err := game.RevealLetter()
+
+	if err != nil || second != 1 || letter != 'I' {
+		t.Fatalf("RevealLetter() again returned %v, %c, %v, expected 1, I.", second, letter, err)
+	}
+
+	if game.Hints != 2 {
+		t.Fatalf("Taking two hints counted %v.", game.Hints)
+	}
+}
+
+func TestGameGiveUp(t *testing.T) {
+
+	game := CreateGame("would")
+	game.MakeGuess("could")
+	game.GiveUp()
+
+	if !game.IsOver() || game.HasWon() || !game.GaveUp {
+		t.Fatalf("Giving up left the game over: %v, won: %v.", game.IsOver(), game.HasWon())
+	}
+}
+
+func TestGameTimeOut(t *testing.T) {
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	countdown := NewCountdown(clock, 10*time.Second, false)
+
+	events := NewEvents()
+	var seen []EventType
+	events.Subscribe(func(e Event) { seen = append(seen, e.Type) })
+
+	game := CreateObservedGame("would", events)
+	game.MakeGuess("could")
+	clock.advance(11 * time.Second)
+
+	if countdown.Expired() {
+		game.TimeOut()
+	}
+
+	if !game.IsOver() || game.HasWon() || !game.TimedOut {
+		t.Fatalf("Running out of time left the game over: %v, won: %v.", game.IsOver(), game.HasWon())
+	}
+
+	if len(seen) == 0 || seen[len(seen)-1] != GameLost {
+		t.Fatalf("Running out of time emitted %v, expected it to end with GameLost.", seen)
+	}
+
+	// A game that is already over can't also time out
+	game.TimeOut()
+
+	if seen[len(seen)-2] == GameLost {
+		t.Fatalf("Timing out twice emitted GameLost twice: %v.", seen)
+	}
+}
+
+func TestCreateGameWithAnswer(t *testing.T) {
+
+	game, err := CreateGameWithAnswer("Fjord", nil)
+
+	if err != nil || game.Answer != "fjord" {
+		t.Fatalf("CreateGameWithAnswer(Fjord) returned %v, %v.", game, err)
+	}
+
+	if _, err := CreateGameWithAnswer("bbbbb", nil); err == nil {
+		t.Fatalf("CreateGameWithAnswer(bbbbb) did not return an error for an invalid word.")
+	}
+}
@@ -0,0 +1,251 @@
+package logic
+
+import (
+	"errors"
+	"sort"
+)
+
+// Everything the results of a game have revealed about the answer.
+// Unlike UsedLetters, which keeps one hint per letter, it keeps what
+// each result says letter by letter and position by position, so
+// repeated letters are counted correctly. Letters are upper case.
+type Knowledge struct {
+	// The letter found at each position, or zero if it isn't known.
+	Fixed []rune
+	// The letters known not to be at each position.
+	Excluded []map[rune]bool
+	// The fewest times each letter known to be in the answer appears.
+	MinCount map[rune]int
+	// The number of times a letter appears, for letters where
+	// it is known exactly. Absent letters are known to appear zero times.
+	ExactCount map[rune]int
+}
+
+// Creates knowledge of an answer of the given length with nothing known.
+func NewKnowledge(length int) *Knowledge {
+
+	k := &Knowledge{
+		Fixed:      make([]rune, length),
+		Excluded:   make([]map[rune]bool, length),
+		MinCount:   make(map[rune]int),
+		ExactCount: make(map[rune]int),
+	}
+
+	for i := range k.Excluded {
+		k.Excluded[i] = make(map[rune]bool)
+	}
+
+	return k
+}
+
+// Builds the knowledge revealed by each guess and its result.
+func BuildKnowledge(guesses []string, results [][]int) (*Knowledge, error) {
+
+	if len(guesses) != len(results) {
+		return nil, errors.New("every guess needs a result")
+	}
+
+	k := NewKnowledge(WordLength)
+
+	for i, guess := range guesses {
+
+		if err := k.Add(guess, results[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return k, nil
+}
+
+// Adds what the result of the guess reveals. Letters in the right
+// position are fixed there, and both letters in the wrong position and
+// letters not in the word are excluded from their position. Every hit
+// on a letter counts towards its minimum, and a miss on a letter that
+// was also hit means there are no more copies than the hits.
+func (k *Knowledge) Add(guess string, result []int) error {
+
+	letters := convertToRunes(guess)
+
+	if len(letters) != len(k.Fixed) || len(result) != len(k.Fixed) {
+		return errors.New("guess and result must match the word length")
+	}
+
+	hits := make(map[rune]int)
+	missed := make(map[rune]bool)
+
+	for i, letter := range letters {
+
+		switch result[i] {
+
+		case CorrectPosition:
+			k.Fixed[i] = letter
+			hits[letter]++
+
+		case WrongPosition:
+			k.Excluded[i][letter] = true
+			hits[letter]++
+
+		default:
+			k.Excluded[i][letter] = true
+			missed[letter] = true
+		}
+	}
+
+	for letter, count := range hits {
+
+		if count > k.MinCount[letter] {
+			k.MinCount[letter] = count
+		}
+	}
+
+	for letter := range missed {
+		k.ExactCount[letter] = hits[letter]
+	}
+
+	return nil
+}
+
+// Returns true if the word fits everything known about the answer.
+func (k *Knowledge) Allows(word string) bool {
+
+	letters := convertToRunes(word)
+
+	if len(letters) != len(k.Fixed) {
+		return false
+	}
+
+	counts := make(map[rune]int)
+
+	for i, letter := range letters {
+
+		if k.Fixed[i] != 0 && k.Fixed[i] != letter {
+			return false
+		}
+
+		// A fixed letter can't be excluded, but guard
+		// against results that contradict each other
+		if k.Fixed[i] == 0 && k.Excluded[i][letter] {
+			return false
+		}
+
+		counts[letter]++
+	}
+
+	for letter, min := range k.MinCount {
+
+		if counts[letter] < min {
+			return false
+		}
+	}
+
+	for letter, exact := range k.ExactCount {
+
+		if counts[letter] != exact {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if the word can be guessed in hard mode: every letter
+// found stays in its position and every letter known to be in the
+// answer is used at least as many times as it is known to appear.
+func (k *Knowledge) AllowsHardMode(word string) bool {
+
+	letters := convertToRunes(word)
+
+	if len(letters) != len(k.Fixed) {
+		return false
+	}
+
+	counts := make(map[rune]int)
+
+	for i, letter := range letters {
+
+		if k.Fixed[i] != 0 && k.Fixed[i] != letter {
+			return false
+		}
+
+		counts[letter]++
+	}
+
+	for letter, min := range k.MinCount {
+
+		if counts[letter] < min {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the letters known not to be in the answer at all, in order.
+func (k *Knowledge) Absent() []rune {
+
+	var absent []rune
+
+	for letter, exact := range k.ExactCount {
+
+		if exact == 0 {
+			absent = append(absent, letter)
+		}
+	}
+
+	return sortRunes(absent)
+}
+
+// Returns the letters known to be in the answer more times than
+// they have been fixed in place, so at least one copy of each still
+// has an unknown position. Letters are in order.
+func (k *Knowledge) Misplaced() []rune {
+
+	var misplaced []rune
+
+	for letter, min := range k.MinCount {
+
+		if min > k.fixedCount(letter) {
+			misplaced = append(misplaced, letter)
+		}
+	}
+
+	return sortRunes(misplaced)
+}
+
+// Returns the letters known to appear more than once, in order.
+func (k *Knowledge) Repeated() []rune {
+
+	var repeated []rune
+
+	for letter, min := range k.MinCount {
+
+		if min > 1 {
+			repeated = append(repeated, letter)
+		}
+	}
+
+	return sortRunes(repeated)
+}
+
+// Returns the number of positions the letter is fixed at.
+func (k *Knowledge) fixedCount(letter rune) int {
+
+	count := 0
+
+	for _, fixed := range k.Fixed {
+
+		if fixed == letter {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Sorts the runes in place and returns them.
+func sortRunes(runes []rune) []rune {
+
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	return runes
+}
@@ -0,0 +1,120 @@
+package logic
+
+import "testing"
+
+// Returns the knowledge from playing the guesses against the answer.
+func knowledgeFor(t *testing.T, answer string, guesses ...string) *Knowledge {
+
+	game := CreateGame(answer)
+
+	for _, guess := range guesses {
+
+		if err := game.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%s) returned an error: %v", guess, err)
+		}
+	}
+
+	return game.Knowledge()
+}
+
+func TestKnowledgeExactCount(t *testing.T) {
+
+	// Only one of the three Es is in the answer
+	k := knowledgeFor(t, "those", "geese")
+
+	if k.Fixed[3] != 'S' || k.Fixed[4] != 'E' {
+		t.Fatalf("Fixed letters were %q.", string(k.Fixed))
+	}
+
+	if k.ExactCount['E'] != 1 || k.MinCount['E'] != 1 || !k.Excluded[1]['E'] || !k.Excluded[2]['E'] {
+		t.Fatalf("E was counted exactly %v, at least %v, excluded %v and %v.", k.ExactCount['E'], k.MinCount['E'], k.Excluded[1], k.Excluded[2])
+	}
+
+	if absent := string(k.Absent()); absent != "G" {
+		t.Fatalf("Absent() returned %q, expected G.", absent)
+	}
+
+	if k.Allows("these") || !k.Allows("those") {
+		t.Fatalf("Allows() accepted a second E or rejected the answer.")
+	}
+}
+
+func TestKnowledgeRepeated(t *testing.T) {
+
+	// Both Es are in the answer, neither in place
+	k := knowledgeFor(t, "speed", "erase")
+
+	if k.MinCount['E'] != 2 {
+		t.Fatalf("E was counted at least %v times.", k.MinCount['E'])
+	}
+
+	if repeated := string(k.Repeated()); repeated != "E" {
+		t.Fatalf("Repeated() returned %q, expected E.", repeated)
+	}
+
+	if misplaced := string(k.Misplaced()); misplaced != "ES" {
+		t.Fatalf("Misplaced() returned %q, expected ES.", misplaced)
+	}
+
+	if _, known := k.ExactCount['E']; known {
+		t.Fatalf("E was counted exactly though no E was gray.")
+	}
+}
+
+func TestKnowledgeMatchesScoring(t *testing.T) {
+
+	games := [][]string{
+		{"those", "geese", "these"},
+		{"speed", "erase", "steep"},
+		{"would", "could", "mould"},
+		{"cigar", "crane", "cacao"},
+	}
+
+	for _, game := range games {
+
+		k := knowledgeFor(t, game[0], game[1:]...)
+		g := CreateGame(game[0])
+
+		for _, guess := range game[1:] {
+			g.MakeGuess(guess)
+		}
+
+		// Knowledge must allow exactly the words that give the same results
+		for _, word := range AnswerWords {
+
+			fits := true
+
+			for i, guess := range g.Guesses {
+
+				result, _ := scoreRunes(convertToRunes(guess), convertToRunes(word))
+
+				if EncodePattern(result) != EncodePattern(g.Results[i]) {
+					fits = false
+				}
+			}
+
+			if k.Allows(word) != fits {
+				t.Fatalf("After %v against %s, Allows(%s) returned %v but the results say %v.", game[1:], game[0], word, k.Allows(word), fits)
+			}
+		}
+	}
+}
+
+func TestKnowledgeAllowsHardMode(t *testing.T) {
+
+	// O and U are found in place, N is somewhere else, C, L and D are out
+	k := knowledgeFor(t, "mount", "could", "dunno")
+
+	if !k.AllowsHardMode("bound") || !k.AllowsHardMode("count") {
+		t.Fatalf("AllowsHardMode() rejected a word keeping O and U and using N.")
+	}
+
+	// Hard mode only holds guesses to the letters found
+	if k.Allows("count") {
+		t.Fatalf("Allows() accepted C, which is known to be absent.")
+	}
+
+	if k.AllowsHardMode("mould") || k.AllowsHardMode("noise") {
+		t.Fatalf("AllowsHardMode() accepted a word dropping a letter found.")
+	}
+}
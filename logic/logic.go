@@ -10,6 +10,9 @@ import (
 
 const MaxGuesses int = 6
 
+// The number of letters in every word.
+const WordLength int = 5
+
 const (
 	// The letter is not in the word.
 	NotInWord int = iota
@@ -38,16 +41,23 @@ func NewGame() {
 	Answer = selectWord()
 }
 
-// Attempt to make a guess with the given string.
-// If the guess string is invalid, an error is returned.
-func MakeGuess(guess string) error {
+// Returns an error if the given word isn't in the dictionary, so
+// can't be played as a guess or chosen as an answer.
+func ValidateWord(word string) error {
 
-	if !isValidWord(guess) {
+	if !isValidWord(word) {
 		return errors.New("must be a valid word")
 	}
 
-	if isDuplicateGuess(guess) {
-		return errors.New("word has already been guessed")
+	return nil
+}
+
+// Attempt to make a guess with the given string.
+// If the guess string is invalid, an error is returned.
+func MakeGuess(guess string) error {
+
+	if err := validateGuess(guess, Guesses); err != nil {
+		return err
 	}
 
 	result, err := compareRunes(convertToRunes(guess), convertToRunes(Answer))
@@ -67,6 +77,21 @@ func HasWon(guess string) bool {
 	return guess == Answer
 }
 
+// Returns an error if guess is not a valid word or
+// is already present in the given previous guesses.
+func validateGuess(guess string, guesses []string) error {
+
+	if err := ValidateWord(guess); err != nil {
+		return err
+	}
+
+	if containsWord(guesses, guess) {
+		return errors.New("word has already been guessed")
+	}
+
+	return nil
+}
+
 // Returns true if the word is a valid word.
 func isValidWord(word string) bool {
 
@@ -83,9 +108,27 @@ func isValidWord(word string) bool {
 // Returns true if the given word was already guessed.
 func isDuplicateGuess(word string) bool {
 
-	for _, guess := range Guesses {
+	return containsWord(Guesses, word)
+}
+
+// Returns a set of the words in lower case.
+func wordSet(words []string) map[string]bool {
+
+	set := make(map[string]bool, len(words))
+
+	for _, word := range words {
+		set[strings.ToLower(word)] = true
+	}
 
-		if strings.EqualFold(guess, word) {
+	return set
+}
+
+// Returns true if words contains word, ignoring case.
+func containsWord(words []string, word string) bool {
+
+	for _, w := range words {
+
+		if strings.EqualFold(w, word) {
 			return true
 		}
 	}
@@ -133,6 +176,21 @@ func getRuneIndices(runes []rune, r rune) []int {
 // If the guess and answer slices are of different lengths, an error is returned.
 func compareRunes(guess []rune, answer []rune) ([]int, error) {
 
+	result, err := scoreRunes(guess, answer)
+
+	if err == nil {
+
+		markUsedLetters(UsedLetters, guess, result)
+	}
+
+	return result, err
+}
+
+// Scores the guess runes against the answer runes without
+// touching any game state. See compareRunes for the meaning
+// of the result.
+func scoreRunes(guess []rune, answer []rune) ([]int, error) {
+
 	if len(guess) != len(answer) {
 
 		return nil, errors.New("must be at least " + strconv.Itoa(len(answer)) + " characters long")
@@ -148,7 +206,6 @@ func compareRunes(guess []rune, answer []rune) ([]int, error) {
 
 			occurences[r] = occurences[r] + 1
 			result[i] = CorrectPosition
-			UsedLetters[r] = CorrectPosition
 		}
 	}
 
@@ -168,22 +225,58 @@ func compareRunes(guess []rune, answer []rune) ([]int, error) {
 		if occurences[r] <= len(answerIndices) {
 
 			result[i] = WrongPosition
+		}
+	}
+
+	return result, nil
+}
+
+// Records the best known state of each rune in the guess
+// into usedLetters, given the result of scoring that guess.
+func markUsedLetters(usedLetters map[rune]int, guess []rune, result []int) {
+
+	// Correct positions always win
+	for i, r := range guess {
 
-			// Don't overwrite correct position status on letters
-			if UsedLetters[r] != CorrectPosition {
+		if result[i] == CorrectPosition {
 
-				UsedLetters[r] = WrongPosition
-			}
+			usedLetters[r] = CorrectPosition
+		}
+	}
+
+	for i, r := range guess {
+
+		if result[i] == CorrectPosition {
+
+			continue
+		}
+
+		// Don't overwrite correct position status on letters
+		if result[i] == WrongPosition && usedLetters[r] != CorrectPosition {
+
+			usedLetters[r] = WrongPosition
 		}
 
 		// Mark any letters that aren't in the word
-		if UsedLetters[r] != WrongPosition && UsedLetters[r] != CorrectPosition {
+		if usedLetters[r] != WrongPosition && usedLetters[r] != CorrectPosition {
 
-			UsedLetters[r] = NotInWord
+			usedLetters[r] = NotInWord
 		}
 	}
+}
 
-	return result, nil
+// Returns a randomly selected answer word, for starting
+// games outside of the package level game state.
+func RandomAnswer() string {
+
+	return selectWord()
+}
+
+// Returns the answer word chosen by the seed. The same
+// seed always gives the same answer, so games can be replayed.
+func SeededAnswer(seed int64) string {
+
+	return AnswerWords[rand.New(rand.NewSource(seed)).Intn(len(AnswerWords))]
 }
 
 // Selects a random word from the list of answer words.
@@ -475,3 +475,14 @@ func TestIsValidWord(t *testing.T) {
 		t.Fatalf("isValidWord(%s) returned true for an invalid word.", word)
 	}
 }
+
+func TestValidateWord(t *testing.T) {
+
+	if err := ValidateWord("Crane"); err != nil {
+		t.Fatalf("ValidateWord(Crane) returned an error for a valid word: %v", err)
+	}
+
+	if err := ValidateWord("bbbbb"); err == nil {
+		t.Fatalf("ValidateWord(bbbbb) returned no error for an invalid word.")
+	}
+}
@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// The number of guesses a marathon run starts with.
+const MarathonStartingPool int = 2 * MaxGuesses
+
+// A run of games played one after another on a single pool of
+// guesses. Every guess comes out of the pool, and solving a word
+// in fewer than MaxGuesses adds the unused guesses back.
+type Marathon struct {
+	Current *Game
+	Pool    int
+	Solved  int
+	// Guesses spent on the words that were solved.
+	SolvedGuesses int
+	remaining     []string
+}
+
+// Starts a new marathon run on the first word.
+func NewMarathon() *Marathon {
+
+	remaining := make([]string, len(AnswerWords))
+
+	for i, j := range rand.Perm(len(AnswerWords)) {
+
+		remaining[i] = AnswerWords[j]
+	}
+
+	m := &Marathon{Pool: MarathonStartingPool, remaining: remaining}
+	m.NextWord()
+
+	return m
+}
+
+// Moves on to a word not yet played this run. If every
+// answer word has been played, an error is returned.
+func (m *Marathon) NextWord() error {
+
+	if len(m.remaining) == 0 {
+		return errors.New("no words left")
+	}
+
+	m.Current = CreateGame(m.remaining[0])
+	m.remaining = m.remaining[1:]
+
+	return nil
+}
+
+// Attempt to make a guess on the current word.
+// If the guess string is invalid, an error is returned.
+func (m *Marathon) MakeGuess(guess string) error {
+
+	if m.IsOver() {
+		return errors.New("no guesses left")
+	}
+
+	if err := m.Current.MakeGuess(guess); err != nil {
+		return err
+	}
+
+	m.Pool--
+
+	if m.Current.HasWon() {
+
+		guesses := len(m.Current.Guesses)
+
+		m.Solved++
+		m.SolvedGuesses += guesses
+		m.Pool += MaxGuesses - guesses
+	}
+
+	return nil
+}
+
+// Returns true if the current word has been solved or has used up
+// all of its guesses, and the run should move on to the next word.
+func (m *Marathon) WordOver() bool {
+
+	return m.Current.HasWon() || len(m.Current.Guesses) >= MaxGuesses || m.IsOver()
+}
+
+// Returns true once the pool of guesses has run out.
+func (m *Marathon) IsOver() bool {
+
+	return m.Pool <= 0
+}
+
+// Returns the mean number of guesses taken on solved words,
+// or zero if none have been solved.
+func (m *Marathon) AverageGuesses() float64 {
+
+	if m.Solved == 0 {
+		return 0
+	}
+
+	return float64(m.SolvedGuesses) / float64(m.Solved)
+}
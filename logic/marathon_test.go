@@ -0,0 +1,77 @@
+package logic
+
+import "testing"
+
+func TestNewMarathon(t *testing.T) {
+
+	m := NewMarathon()
+
+	if m.Pool != MarathonStartingPool || m.Current == nil {
+		t.Fatalf("NewMarathon() started with pool %v and game %v.", m.Pool, m.Current)
+	}
+
+	// Every answer word is played once before running out
+	seen := map[string]bool{m.Current.Answer: true}
+
+	for m.NextWord() == nil {
+
+		if seen[m.Current.Answer] {
+			t.Fatalf("NextWord() repeated %s.", m.Current.Answer)
+		}
+
+		seen[m.Current.Answer] = true
+	}
+
+	if len(seen) != len(AnswerWords) {
+		t.Fatalf("NextWord() played %v words, expected %v.", len(seen), len(AnswerWords))
+	}
+}
+
+func TestMarathonMakeGuess(t *testing.T) {
+
+	m := NewMarathon()
+	m.Current = CreateGame("piety")
+
+	// Solving in two refunds the unused guesses
+	m.MakeGuess("crane")
+	m.MakeGuess("piety")
+
+	expectedPool := MarathonStartingPool - 2 + MaxGuesses - 2
+
+	if m.Pool != expectedPool || m.Solved != 1 || !m.WordOver() {
+		t.Fatalf("Solving in 2 left pool %v with %v solved, expected %v with 1.", m.Pool, m.Solved, expectedPool)
+	}
+
+	// Invalid guesses cost nothing
+	m.NextWord()
+	m.Current = CreateGame("piety")
+
+	if m.MakeGuess("aaaaa") == nil || m.Pool != expectedPool {
+		t.Fatalf("MakeGuess(aaaaa) was accepted or cost a guess. Pool: %v.", m.Pool)
+	}
+
+	// Missing a word refunds nothing
+	for _, guess := range []string{"crane", "slate", "fjord", "about", "hello", "world"} {
+		m.MakeGuess(guess)
+	}
+
+	expectedPool -= MaxGuesses
+
+	if m.Pool != expectedPool || m.Solved != 1 || !m.WordOver() {
+		t.Fatalf("Missing a word left pool %v with %v solved, expected %v with 1.", m.Pool, m.Solved, expectedPool)
+	}
+
+	if m.AverageGuesses() != 2 {
+		t.Fatalf("AverageGuesses() returned %v, expected 2.", m.AverageGuesses())
+	}
+
+	// Using up the pool ends the run
+	m.Pool = 1
+	m.NextWord()
+	m.Current = CreateGame("piety")
+	m.MakeGuess("crane")
+
+	if !m.IsOver() || m.MakeGuess("slate") == nil {
+		t.Fatal("Run continued after the pool ran out.")
+	}
+}
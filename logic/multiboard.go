@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// The supported number of boards in a multi-board game.
+var BoardCounts = []int{2, 4, 8, 16}
+
+// A game in which every guess is scored against
+// several independent answers at once.
+type MultiGame struct {
+	Boards     []*Game
+	Guesses    []string
+	MaxGuesses int
+}
+
+// Starts a new multi-board game with the given number of boards.
+// Each board has its own answer and the guess limit grows with
+// the number of boards.
+func NewMultiGame(boards int) (*MultiGame, error) {
+
+	if !isBoardCount(boards) {
+		return nil, errors.New("unsupported number of boards")
+	}
+
+	game := &MultiGame{MaxGuesses: boards + MaxGuesses - 1}
+
+	for _, i := range rand.Perm(len(AnswerWords))[:boards] {
+
+		board := CreateGame(AnswerWords[i])
+		board.MaxGuesses = game.MaxGuesses
+		game.Boards = append(game.Boards, board)
+	}
+
+	return game, nil
+}
+
+// Attempt to make a guess against every unsolved board.
+// If the guess string is invalid, an error is returned.
+func (m *MultiGame) MakeGuess(guess string) error {
+
+	if err := validateGuess(guess, m.Guesses); err != nil {
+		return err
+	}
+
+	for _, board := range m.Boards {
+
+		// Solved boards stop taking guesses
+		if board.HasWon() {
+			continue
+		}
+
+		if err := board.MakeGuess(guess); err != nil {
+			return err
+		}
+	}
+
+	m.Guesses = append(m.Guesses, guess)
+
+	return nil
+}
+
+// Returns the number of boards that have been solved.
+func (m *MultiGame) Solved() int {
+
+	solved := 0
+
+	for _, board := range m.Boards {
+
+		if board.HasWon() {
+			solved++
+		}
+	}
+
+	return solved
+}
+
+// Returns true if every board has been solved.
+func (m *MultiGame) HasWon() bool {
+
+	return m.Solved() == len(m.Boards)
+}
+
+// Returns true if the game has been won or all guesses are used up.
+func (m *MultiGame) IsOver() bool {
+
+	return m.HasWon() || len(m.Guesses) >= m.MaxGuesses
+}
+
+// Returns true if boards is a supported number of boards.
+func isBoardCount(boards int) bool {
+
+	for _, count := range BoardCounts {
+
+		if boards == count {
+			return true
+		}
+	}
+
+	return false
+}
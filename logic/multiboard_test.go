@@ -0,0 +1,77 @@
+package logic
+
+import "testing"
+
+func TestNewMultiGame(t *testing.T) {
+
+	for _, boards := range BoardCounts {
+
+		game, err := NewMultiGame(boards)
+
+		if err != nil {
+			t.Fatalf("NewMultiGame(%v) returned an error: %v", boards, err)
+		}
+
+		if len(game.Boards) != boards {
+			t.Fatalf("NewMultiGame(%v) created %v boards.", boards, len(game.Boards))
+		}
+
+		if game.MaxGuesses <= MaxGuesses {
+			t.Fatalf("NewMultiGame(%v) allowed only %v guesses.", boards, game.MaxGuesses)
+		}
+
+		answers := make(map[string]bool)
+
+		for _, board := range game.Boards {
+
+			if !isValidAnswerWord(board.Answer) {
+				t.Fatalf("NewMultiGame(%v) chose %s, which is not an answer word.", boards, board.Answer)
+			}
+
+			if answers[board.Answer] {
+				t.Fatalf("NewMultiGame(%v) chose %s for more than one board.", boards, board.Answer)
+			}
+
+			answers[board.Answer] = true
+		}
+	}
+
+	if _, err := NewMultiGame(3); err == nil {
+		t.Fatal("NewMultiGame(3) did not return an error.")
+	}
+}
+
+func TestMultiGameMakeGuess(t *testing.T) {
+
+	game := &MultiGame{
+		Boards:     []*Game{CreateGame("piety"), CreateGame("crane")},
+		MaxGuesses: 7,
+	}
+
+	// Solves the first board only
+	if err := game.MakeGuess("piety"); err != nil {
+		t.Fatalf("MakeGuess(piety) returned an error: %v", err)
+	}
+
+	if game.Solved() != 1 || game.HasWon() || game.IsOver() {
+		t.Fatalf("MakeGuess(piety) solved %v boards, expected 1.", game.Solved())
+	}
+
+	// Solved boards take no further guesses
+	if err := game.MakeGuess("crane"); err != nil {
+		t.Fatalf("MakeGuess(crane) returned an error: %v", err)
+	}
+
+	if len(game.Boards[0].Results) != 1 || len(game.Boards[1].Results) != 2 {
+		t.Fatalf("MakeGuess scored solved boards. Results: %v, %v.", game.Boards[0].Results, game.Boards[1].Results)
+	}
+
+	if !game.HasWon() || !game.IsOver() {
+		t.Fatal("HasWon() returned false with every board solved.")
+	}
+
+	// Duplicate guess
+	if game.MakeGuess("piety") == nil {
+		t.Fatal("MakeGuess(piety) allowed a duplicate word.")
+	}
+}
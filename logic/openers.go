@@ -0,0 +1,159 @@
+package logic
+
+import "sort"
+
+// The most guesses a solve is simulated for before giving up on it.
+const maxSolveGuesses = 20
+
+// How a word does as the first guess of a game.
+type OpenerScore struct {
+	Word string
+	// The mean number of answers left after the guess.
+	Expected float64
+	// The information the guess is expected to gain, in bits.
+	Entropy float64
+	// The most answers that can be left after the guess.
+	Worst int
+	// The mean number of guesses taken to solve every answer when
+	// opening with the word, or zero if it wasn't worked out.
+	AverageSolve float64
+}
+
+// Scores every valid word as a first guess against the answer
+// words, in the order of the valid words. The words are split
+// between goroutines.
+func ScoreOpeners() []OpenerScore {
+
+	m := DefaultMatrix()
+	columns := indexes(len(m.Answers))
+	scores := make([]OpenerScore, len(m.Guesses))
+
+	parallelFor(len(m.Guesses), func(g int) {
+
+		sizes := m.groupSizes(g, columns)
+		scores[g] = scoreOpener(m.Guesses[g], &sizes, len(columns))
+	})
+
+	return scores
+}
+
+// Scores a first guess from the number of answers, out
+// of the total, that would give it each pattern.
+func scoreOpener(word string, sizes *[PatternCount]int, total int) OpenerScore {
+
+	score := OpenerScore{Word: word, Entropy: entropy(sizes, total)}
+
+	for _, size := range sizes {
+
+		score.Expected += float64(size) * float64(size) / float64(total)
+
+		if size > score.Worst {
+			score.Worst = size
+		}
+	}
+
+	return score
+}
+
+// Returns the mean number of guesses taken to solve every answer
+// word when opening with the word and choosing each later guess
+// with the strategy. Answers not solved within a reasonable
+// number of guesses count as that many.
+func AverageSolveLength(opener string, strategy Strategy) float64 {
+
+	total := 0
+
+	for _, answer := range AnswerWords {
+		total += solveLength(opener, answer, strategy)
+	}
+
+	return float64(total) / float64(len(AnswerWords))
+}
+
+// Returns the number of guesses taken to solve the answer.
+func solveLength(opener string, answer string, strategy Strategy) int {
+
+	state := SolverState{Candidates: AnswerWords}
+	guess := opener
+
+	for guesses := 1; guesses < maxSolveGuesses; guesses++ {
+
+		if guess == answer {
+			return guesses
+		}
+
+		pattern := ScorePattern(guess, answer)
+		state.Guesses = append(state.Guesses, guess)
+		state.Results = append(state.Results, pattern.Result())
+		state.Candidates = filterByPattern(state.Candidates, guess, pattern)
+
+		if len(state.Candidates) == 0 {
+			break
+		}
+
+		guess = strategy.NextGuess(state)
+	}
+
+	return maxSolveGuesses
+}
+
+// Returns the candidate expected to gain the most against the others.
+// Ties go to the candidate first in the list.
+func bestCandidate(candidates []string) string {
+
+	m, columns := candidateMatrix(candidates)
+	best, bestBits := 0, -1.0
+
+	for i, candidate := range candidates {
+
+		g, ok := m.GuessIndex(candidate)
+
+		if !ok {
+			continue
+		}
+
+		sizes := m.groupSizes(g, columns)
+
+		if bits := entropy(&sizes, len(columns)); bits > bestBits+1e-9 {
+			best, bestBits = i, bits
+		}
+	}
+
+	return candidates[best]
+}
+
+// Sorts the scores by the named column: "word", "expected", "entropy",
+// "worst" or "solve". Better scores come first, and ties go in word
+// order. Returns false if the column isn't known.
+func SortOpeners(scores []OpenerScore, column string) bool {
+
+	better := map[string]func(a, b OpenerScore) bool{
+		"word":     func(a, b OpenerScore) bool { return a.Word < b.Word },
+		"expected": func(a, b OpenerScore) bool { return a.Expected < b.Expected },
+		"entropy":  func(a, b OpenerScore) bool { return a.Entropy > b.Entropy },
+		"worst":    func(a, b OpenerScore) bool { return a.Worst < b.Worst },
+		// Scores without a solve length go last
+		"solve": func(a, b OpenerScore) bool {
+			return a.AverageSolve != 0 && (b.AverageSolve == 0 || a.AverageSolve < b.AverageSolve)
+		},
+	}[column]
+
+	if better == nil {
+		return false
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+
+		if better(scores[i], scores[j]) {
+			return true
+		}
+
+		if better(scores[j], scores[i]) {
+			return false
+		}
+
+		return scores[i].Word < scores[j].Word
+	})
+
+	return true
+}
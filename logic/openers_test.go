@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreOpener(t *testing.T) {
+
+	// WOULD and COULD give the same result for MOULD, FOUND doesn't
+	var sizes [PatternCount]int
+
+	for _, answer := range []string{"would", "could", "found"} {
+		sizes[ScorePattern("mould", answer)]++
+	}
+
+	score := scoreOpener("mould", &sizes, 3)
+
+	if score.Worst != 2 || math.Abs(score.Expected-5.0/3) > 1e-9 {
+		t.Fatalf("scoreOpener gave worst %v and expected %v, expected 2 and 1.67.", score.Worst, score.Expected)
+	}
+
+	if math.Abs(score.Entropy-(math.Log2(3)-2.0/3)) > 1e-9 {
+		t.Fatalf("scoreOpener gave entropy %v.", score.Entropy)
+	}
+}
+
+func TestAverageSolveLength(t *testing.T) {
+
+	for _, name := range []string{"first", "candidates"} {
+
+		average := AverageSolveLength(AnswerWords[0], Strategies[name])
+
+		// Every answer but the opener takes at least two guesses
+		if average < 2-1.0/float64(len(AnswerWords)) || average >= maxSolveGuesses {
+			t.Fatalf("AverageSolveLength under %s returned %v.", name, average)
+		}
+	}
+}
+
+func TestSortOpeners(t *testing.T) {
+
+	scores := []OpenerScore{
+		{Word: "b", Entropy: 5, Worst: 30},
+		{Word: "a", Entropy: 6, Worst: 30, AverageSolve: 3.5},
+		{Word: "c", Entropy: 4, Worst: 20, AverageSolve: 3.4},
+	}
+
+	tests := map[string]string{"entropy": "abc", "worst": "cab", "solve": "cab", "word": "abc"}
+
+	for column, expected := range tests {
+
+		if !SortOpeners(scores, column) {
+			t.Fatalf("SortOpeners did not know column %s.", column)
+		}
+
+		if order := scores[0].Word + scores[1].Word + scores[2].Word; order != expected {
+			t.Fatalf("Sorting by %s gave %s, expected %s.", column, order, expected)
+		}
+	}
+
+	if SortOpeners(scores, "bogus") {
+		t.Fatalf("SortOpeners accepted an unknown column.")
+	}
+}
@@ -0,0 +1,43 @@
+package logic
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Calls fn with every index from 0 to n-1, spreading the calls over a
+// goroutine for each CPU, and returns once every call has returned.
+// Each goroutine takes every workers-th index, so calls for nearby
+// indexes, which tend to cost the same, are shared out evenly.
+func parallelFor(n int, fn func(i int)) {
+
+	parallelForContext(context.Background(), n, fn)
+}
+
+// Calls fn with the indexes as parallelFor does, but stops making calls
+// once the context is done, returning its error. Calls already made
+// are finished first.
+func parallelForContext(ctx context.Context, n int, fn func(i int)) error {
+
+	workers := min(runtime.NumCPU(), n)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+
+		wg.Add(1)
+		go func(w int) {
+
+			defer wg.Done()
+
+			for i := w; i < n && ctx.Err() == nil; i += workers {
+				fn(i)
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
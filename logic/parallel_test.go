@@ -0,0 +1,41 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelFor(t *testing.T) {
+
+	for _, n := range []int{0, 1, 7, 1000} {
+
+		calls := make([]atomic.Int32, n)
+		parallelFor(n, func(i int) { calls[i].Add(1) })
+
+		for i := range calls {
+
+			if c := calls[i].Load(); c != 1 {
+				t.Fatalf("parallelFor(%v) called index %v %v times.", n, i, c)
+			}
+		}
+	}
+}
+
+func TestParallelForContext(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+
+	err := parallelForContext(ctx, 10000, func(i int) {
+
+		calls.Add(1)
+		cancel()
+	})
+
+	if !errors.Is(err, context.Canceled) || calls.Load() > int32(runtime.NumCPU()) {
+		t.Fatalf("parallelForContext made %v calls after being cancelled, returning %v.", calls.Load(), err)
+	}
+}
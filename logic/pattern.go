@@ -0,0 +1,381 @@
+package logic
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// A result encoded as a single number: each hint is a base 3 digit,
+// the first letter's hint being the most significant. Every result
+// for a word of WordLength letters fits in a byte.
+type Pattern uint8
+
+// The number of different patterns a word of WordLength letters can get.
+const PatternCount = 243
+
+// Encodes the result as a pattern.
+func EncodePattern(result []int) Pattern {
+
+	pattern := 0
+
+	for _, hint := range result {
+		pattern = pattern*3 + hint
+	}
+
+	return Pattern(pattern)
+}
+
+// Decodes the pattern back into a result with one hint per letter.
+func (p Pattern) Result() []int {
+
+	result := make([]int, WordLength)
+
+	for i := WordLength - 1; i >= 0; i-- {
+
+		result[i] = int(p % 3)
+		p /= 3
+	}
+
+	return result
+}
+
+// Returns the pattern written as FormatPattern writes its result.
+func (p Pattern) String() string {
+
+	return FormatPattern(p.Result())
+}
+
+// Scores the guess against the answer as compareRunes does and returns
+// the encoded result. Words of WordLength ASCII letters are scored
+// without allocating; anything else falls back to scoring runes,
+// giving the pattern of a word of gray letters if they can't be scored.
+func ScorePattern(guess string, answer string) Pattern {
+
+	if len(guess) != WordLength || len(answer) != WordLength || !isASCIILetters(guess) || !isASCIILetters(answer) {
+
+		result, err := scoreRunes(convertToRunes(guess), convertToRunes(answer))
+
+		if err != nil || len(result) != WordLength {
+			return 0
+		}
+
+		return EncodePattern(result)
+	}
+
+	var hints [WordLength]int
+	var unmatched [26]int
+
+	// Greens first, counting the answer letters left for yellows
+	for i := 0; i < WordLength; i++ {
+
+		g, a := lowerLetter(guess[i]), lowerLetter(answer[i])
+
+		if g == a {
+			hints[i] = CorrectPosition
+		} else {
+			unmatched[a]++
+		}
+	}
+
+	pattern := 0
+
+	for i := 0; i < WordLength; i++ {
+
+		if hints[i] != CorrectPosition {
+
+			if g := lowerLetter(guess[i]); unmatched[g] > 0 {
+
+				unmatched[g]--
+				hints[i] = WrongPosition
+			}
+		}
+
+		pattern = pattern*3 + hints[i]
+	}
+
+	return Pattern(pattern)
+}
+
+// Returns true if every byte of the word is an ASCII letter.
+func isASCIILetters(word string) bool {
+
+	for i := 0; i < len(word); i++ {
+
+		if c := word[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the position of the ASCII letter in the alphabet, from zero.
+func lowerLetter(c byte) byte {
+
+	return (c | 0x20) - 'a'
+}
+
+// The pattern of every guess against every answer, worked out once so
+// solvers can look patterns up instead of scoring them over and over.
+type PatternMatrix struct {
+	Guesses []string
+	Answers []string
+	// The patterns row by row, one row per guess.
+	patterns     []Pattern
+	guessIndex   map[string]int
+	answerIndex  map[string]int
+	indexesBuilt sync.Once
+}
+
+// Builds the matrix of the guesses against the answers. The rows
+// are split between goroutines.
+func NewPatternMatrix(guesses []string, answers []string) *PatternMatrix {
+
+	m := &PatternMatrix{
+		Guesses:  guesses,
+		Answers:  answers,
+		patterns: make([]Pattern, len(guesses)*len(answers)),
+	}
+
+	parallelFor(len(guesses), func(g int) {
+
+		row := m.Row(g)
+
+		for a, answer := range answers {
+			row[a] = ScorePattern(guesses[g], answer)
+		}
+	})
+
+	return m
+}
+
+// Returns the pattern of the guess at row g against the answer at column a.
+func (m *PatternMatrix) At(g int, a int) Pattern {
+
+	return m.patterns[g*len(m.Answers)+a]
+}
+
+// Returns the patterns of the guess at row g against every answer.
+// The row is part of the matrix, so it must not be changed.
+func (m *PatternMatrix) Row(g int) []Pattern {
+
+	return m.patterns[g*len(m.Answers) : (g+1)*len(m.Answers)]
+}
+
+// Returns the row of the guess, if it is in the matrix.
+func (m *PatternMatrix) GuessIndex(guess string) (int, bool) {
+
+	m.buildIndexes()
+	g, ok := m.guessIndex[strings.ToLower(guess)]
+
+	return g, ok
+}
+
+// Returns the column of the answer, if it is in the matrix.
+func (m *PatternMatrix) AnswerIndex(answer string) (int, bool) {
+
+	m.buildIndexes()
+	a, ok := m.answerIndex[strings.ToLower(answer)]
+
+	return a, ok
+}
+
+// Builds the lookups from words to rows and columns the first time they're needed.
+func (m *PatternMatrix) buildIndexes() {
+
+	m.indexesBuilt.Do(func() {
+
+		m.guessIndex = make(map[string]int, len(m.Guesses))
+		m.answerIndex = make(map[string]int, len(m.Answers))
+
+		for g, guess := range m.Guesses {
+			m.guessIndex[strings.ToLower(guess)] = g
+		}
+
+		for a, answer := range m.Answers {
+			m.answerIndex[strings.ToLower(answer)] = a
+		}
+	})
+}
+
+// Returns the number of answers in the columns that give each pattern for the guess at row g.
+func (m *PatternMatrix) groupSizes(g int, columns []int) [PatternCount]int {
+
+	var sizes [PatternCount]int
+	row := m.Row(g)
+
+	for _, a := range columns {
+		sizes[row[a]]++
+	}
+
+	return sizes
+}
+
+// The first bytes of a matrix cache file.
+const matrixMagic = "GODLEPM1"
+
+// Returns a key that changes whenever the words change, so a
+// cache file can be checked against the lists it was built from.
+func matrixKey(guesses []string, answers []string) [sha256.Size]byte {
+
+	return sha256.Sum256([]byte(strings.Join(guesses, ",") + "\n" + strings.Join(answers, ",")))
+}
+
+// Writes the matrix in the cache file format: a header with the key of
+// the words and the size of the matrix, then one byte per pattern.
+func (m *PatternMatrix) WriteTo(w io.Writer) (int64, error) {
+
+	key := matrixKey(m.Guesses, m.Answers)
+	header := make([]byte, 0, len(matrixMagic)+len(key)+8)
+	header = append(header, matrixMagic...)
+	header = append(header, key[:]...)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(m.Guesses)))
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(m.Answers)))
+
+	n, err := w.Write(header)
+
+	if err != nil {
+		return int64(n), err
+	}
+
+	data := make([]byte, len(m.patterns))
+
+	for i, p := range m.patterns {
+		data[i] = byte(p)
+	}
+
+	written, err := w.Write(data)
+
+	return int64(n + written), err
+}
+
+// Reads a matrix of the guesses against the answers written by WriteTo.
+// An error is returned if it was built from different words.
+func ReadPatternMatrix(r io.Reader, guesses []string, answers []string) (*PatternMatrix, error) {
+
+	key := matrixKey(guesses, answers)
+	header := make([]byte, len(matrixMagic)+len(key)+8)
+
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	if string(header[:len(matrixMagic)]) != matrixMagic {
+		return nil, errors.New("not a pattern matrix")
+	}
+
+	sizes := header[len(matrixMagic)+len(key):]
+
+	if string(header[len(matrixMagic):len(matrixMagic)+len(key)]) != string(key[:]) ||
+		binary.LittleEndian.Uint32(sizes) != uint32(len(guesses)) ||
+		binary.LittleEndian.Uint32(sizes[4:]) != uint32(len(answers)) {
+
+		return nil, errors.New("pattern matrix was built from different words")
+	}
+
+	data := make([]byte, len(guesses)*len(answers))
+
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+
+	m := &PatternMatrix{Guesses: guesses, Answers: answers, patterns: make([]Pattern, len(data))}
+
+	for i, b := range data {
+
+		if b >= PatternCount {
+			return nil, errors.New("pattern matrix is corrupt")
+		}
+
+		m.patterns[i] = Pattern(b)
+	}
+
+	return m, nil
+}
+
+// Loads the matrix of the guesses against the answers from the cache
+// file at path. If the file is missing or was built from other words,
+// the matrix is built and saved there instead. Failing to save it
+// is returned along with the built matrix.
+func LoadPatternMatrix(path string, guesses []string, answers []string) (*PatternMatrix, error) {
+
+	if f, err := os.Open(path); err == nil {
+
+		m, err := ReadPatternMatrix(bufio.NewReader(f), guesses, answers)
+		f.Close()
+
+		if err == nil {
+			return m, nil
+		}
+	}
+
+	m := NewPatternMatrix(guesses, answers)
+
+	return m, saveMatrix(path, m)
+}
+
+// Saves the matrix to path, creating its directory if needed.
+func saveMatrix(path string, m *PatternMatrix) error {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	_, err = m.WriteTo(w)
+
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+var (
+	defaultMatrixMu sync.Mutex
+	defaultMatrix   *PatternMatrix
+)
+
+// Returns the matrix of every valid word against every answer word,
+// building it the first time it is needed.
+func DefaultMatrix() *PatternMatrix {
+
+	defaultMatrixMu.Lock()
+	defer defaultMatrixMu.Unlock()
+
+	if defaultMatrix == nil {
+		defaultMatrix = NewPatternMatrix(ValidWords, AnswerWords)
+	}
+
+	return defaultMatrix
+}
+
+// Loads the default matrix from the cache file at path, building it and
+// saving it there if needed. Call it before the matrix is first used.
+func UseMatrixCache(path string) error {
+
+	m, err := LoadPatternMatrix(path, ValidWords, AnswerWords)
+
+	defaultMatrixMu.Lock()
+	defaultMatrix = m
+	defaultMatrixMu.Unlock()
+
+	return err
+}
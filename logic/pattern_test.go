@@ -0,0 +1,117 @@
+package logic
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestScorePatternMatchesScoreRunes(t *testing.T) {
+
+	guesses := append([]string{"speed", "eerie", "Asian"}, ValidWords[:200]...)
+
+	for _, guess := range guesses {
+
+		for _, answer := range AnswerWords {
+
+			result, _ := scoreRunes(convertToRunes(guess), convertToRunes(answer))
+
+			if got := ScorePattern(guess, answer); got != EncodePattern(result) {
+				t.Fatalf("ScorePattern(%q, %q) returned %v, expected %v.", guess, answer, got, FormatPattern(result))
+			}
+		}
+	}
+}
+
+func TestPatternRoundTrip(t *testing.T) {
+
+	for p := Pattern(0); p < PatternCount; p++ {
+
+		if got := EncodePattern(p.Result()); got != p {
+			t.Fatalf("Pattern %v decoded and encoded again as %v.", p, got)
+		}
+	}
+
+	if got := EncodePattern([]int{CorrectPosition, WrongPosition, NotInWord, NotInWord, CorrectPosition}).String(); got != "gybbg" {
+		t.Fatalf("Pattern was written as %q, expected \"gybbg\".", got)
+	}
+}
+
+func TestPatternMatrixReadWrite(t *testing.T) {
+
+	guesses := ValidWords[:50]
+	answers := AnswerWords[:40]
+	m := NewPatternMatrix(guesses, answers)
+
+	var buffer bytes.Buffer
+
+	if _, err := m.WriteTo(&buffer); err != nil {
+		t.Fatalf("WriteTo returned an error: %v.", err)
+	}
+
+	data := buffer.Bytes()
+	read, err := ReadPatternMatrix(bytes.NewReader(data), guesses, answers)
+
+	if err != nil {
+		t.Fatalf("ReadPatternMatrix returned an error: %v.", err)
+	}
+
+	for g := range guesses {
+
+		for a := range answers {
+
+			if read.At(g, a) != m.At(g, a) {
+				t.Fatalf("Read matrix differs at %v, %v.", g, a)
+			}
+		}
+	}
+
+	if _, err := ReadPatternMatrix(bytes.NewReader(data), guesses, AnswerWords[1:41]); err == nil {
+		t.Fatalf("ReadPatternMatrix accepted a matrix built from other words.")
+	}
+}
+
+func TestAnswerIndexIgnoresCase(t *testing.T) {
+
+	m := NewPatternMatrix([]string{"crane"}, []string{"Asian", "crane"})
+
+	if a, ok := m.AnswerIndex("asian"); !ok || a != 0 {
+		t.Fatalf("AnswerIndex returned %v, %v, expected 0, true.", a, ok)
+	}
+
+	if a, ok := m.AnswerIndex("CRANE"); !ok || a != 1 {
+		t.Fatalf("AnswerIndex returned %v, %v, expected 1, true.", a, ok)
+	}
+}
+
+func BenchmarkScoreRunes(b *testing.B) {
+
+	guess, answer := convertToRunes("eerie"), convertToRunes("sheep")
+
+	for i := 0; i < b.N; i++ {
+		scoreRunes(guess, answer)
+	}
+}
+
+func BenchmarkScorePattern(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+		ScorePattern("eerie", "sheep")
+	}
+}
+
+func BenchmarkMatrixLookup(b *testing.B) {
+
+	m := DefaultMatrix()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.At(i%len(m.Guesses), i%len(m.Answers))
+	}
+}
+
+func BenchmarkBuildMatrix(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+		NewPatternMatrix(ValidWords, AnswerWords)
+	}
+}
@@ -0,0 +1,155 @@
+package logic
+
+import "strings"
+
+// The number of guesses allowed in a game scored by letter counts only.
+const JottoMaxGuesses int = 10
+
+// The feedback given for a single guess.
+type Feedback interface {
+	// Returns true if the feedback shows the guess was the answer.
+	Solved() bool
+	// Records whatever the feedback reveals about the letters
+	// of the guess into usedLetters.
+	MarkLetters(usedLetters map[rune]int, guess string)
+}
+
+// Scores a guess against an answer.
+type Scorer interface {
+	Score(guess string, answer string) (Feedback, error)
+}
+
+// Feedback for every position of the guess, as given by compareRunes.
+type PositionalFeedback []int
+
+func (p PositionalFeedback) Solved() bool {
+
+	return isSolvedResult(p)
+}
+
+func (p PositionalFeedback) MarkLetters(usedLetters map[rune]int, guess string) {
+
+	markUsedLetters(usedLetters, convertToRunes(guess), p)
+}
+
+// Feedback that only counts the letters in the correct
+// and wrong positions without saying which ones they are.
+type AggregateFeedback struct {
+	Correct       int
+	WrongPosition int
+	Length        int
+}
+
+func (a AggregateFeedback) Solved() bool {
+
+	return a.Length > 0 && a.Correct == a.Length
+}
+
+// Only letters from a guess with nothing in the word are
+// marked, since anything else would reveal positions.
+func (a AggregateFeedback) MarkLetters(usedLetters map[rune]int, guess string) {
+
+	if a.Correct+a.WrongPosition > 0 {
+		return
+	}
+
+	for _, r := range convertToRunes(guess) {
+
+		usedLetters[r] = NotInWord
+	}
+}
+
+// Scores guesses with a result for every position.
+type PositionalScorer struct{}
+
+func (PositionalScorer) Score(guess string, answer string) (Feedback, error) {
+
+	result, err := scoreRunes(convertToRunes(guess), convertToRunes(answer))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return PositionalFeedback(result), nil
+}
+
+// Scores guesses with counts of correct and misplaced letters only.
+type AggregateScorer struct{}
+
+func (AggregateScorer) Score(guess string, answer string) (Feedback, error) {
+
+	result, err := scoreRunes(convertToRunes(guess), convertToRunes(answer))
+
+	if err != nil {
+		return nil, err
+	}
+
+	correct, wrong := countHints(result)
+
+	return AggregateFeedback{Correct: correct, WrongPosition: wrong, Length: len(result)}, nil
+}
+
+// A game scored by any Scorer.
+type ScoredGame struct {
+	Scorer      Scorer
+	Answer      string
+	Guesses     []string
+	Feedback    []Feedback
+	UsedLetters map[rune]int
+	MaxGuesses  int
+}
+
+// Starts a new game scored by the given scorer.
+func NewScoredGame(scorer Scorer, maxGuesses int) *ScoredGame {
+
+	return &ScoredGame{
+		Scorer:      scorer,
+		Answer:      selectWord(),
+		UsedLetters: make(map[rune]int),
+		MaxGuesses:  maxGuesses,
+	}
+}
+
+// Starts a new game where guesses only reveal how many letters
+// are in the correct and wrong positions.
+func NewJottoGame() *ScoredGame {
+
+	return NewScoredGame(AggregateScorer{}, JottoMaxGuesses)
+}
+
+// Attempt to make a guess with the given string.
+// If the guess string is invalid, an error is returned.
+func (s *ScoredGame) MakeGuess(guess string) error {
+
+	if err := validateGuess(guess, s.Guesses); err != nil {
+		return err
+	}
+
+	feedback, err := s.Scorer.Score(guess, s.Answer)
+
+	if err != nil {
+		return err
+	}
+
+	feedback.MarkLetters(s.UsedLetters, guess)
+	s.Guesses = append(s.Guesses, guess)
+	s.Feedback = append(s.Feedback, feedback)
+
+	return nil
+}
+
+// Returns true if the last guess made was the answer.
+func (s *ScoredGame) HasWon() bool {
+
+	if len(s.Guesses) == 0 {
+		return false
+	}
+
+	return strings.EqualFold(s.Guesses[len(s.Guesses)-1], s.Answer)
+}
+
+// Returns true if the game has been won or all guesses are used up.
+func (s *ScoredGame) IsOver() bool {
+
+	return s.HasWon() || len(s.Guesses) >= s.MaxGuesses
+}
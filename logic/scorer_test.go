@@ -0,0 +1,88 @@
+package logic
+
+import "testing"
+
+func TestPositionalScorer(t *testing.T) {
+
+	feedback, err := PositionalScorer{}.Score("valid", "vilag")
+
+	if err != nil {
+		t.Fatalf("Score(valid, vilag) returned an error: %v", err)
+	}
+
+	expected := []int{1, 2, 1, 2, 0}
+
+	if !resultsEqual(feedback.(PositionalFeedback), expected) {
+		t.Fatalf("Score(valid, vilag) returned %v, expected %v.", feedback, expected)
+	}
+
+	if feedback.Solved() {
+		t.Fatal("Solved() returned true for an incorrect guess.")
+	}
+
+	if _, err := (PositionalScorer{}).Score("abc", "vilag"); err == nil {
+		t.Fatal("Score(abc, vilag) did not return an error.")
+	}
+}
+
+func TestAggregateScorer(t *testing.T) {
+
+	feedback, err := AggregateScorer{}.Score("valid", "vilag")
+
+	if err != nil {
+		t.Fatalf("Score(valid, vilag) returned an error: %v", err)
+	}
+
+	expected := AggregateFeedback{Correct: 2, WrongPosition: 2, Length: 5}
+
+	if feedback != expected {
+		t.Fatalf("Score(valid, vilag) returned %v, expected %v.", feedback, expected)
+	}
+
+	feedback, _ = AggregateScorer{}.Score("piety", "piety")
+
+	if !feedback.Solved() {
+		t.Fatal("Solved() returned false for a correct guess.")
+	}
+}
+
+func TestAggregateFeedbackMarkLetters(t *testing.T) {
+
+	// Letters of a guess with hits must not be marked
+	usedLetters := make(map[rune]int)
+	AggregateFeedback{Correct: 1, Length: 5}.MarkLetters(usedLetters, "crane")
+
+	if len(usedLetters) != 0 {
+		t.Fatalf("MarkLetters revealed letters of a guess with hits: %v.", usedLetters)
+	}
+
+	// A guess with no hits rules out all of its letters
+	AggregateFeedback{Length: 5}.MarkLetters(usedLetters, "fjord")
+	expected := map[rune]int{'F': 0, 'J': 0, 'O': 0, 'R': 0, 'D': 0}
+
+	if !usedLettersEqual(usedLetters, expected) {
+		t.Fatalf("MarkLetters marked %v, expected %v.", usedLetters, expected)
+	}
+}
+
+func TestJottoGame(t *testing.T) {
+
+	game := NewJottoGame()
+	game.Answer = "piety"
+
+	if game.MakeGuess("aaaaa") == nil {
+		t.Fatal("MakeGuess(aaaaa) allowed an invalid word.")
+	}
+
+	game.MakeGuess("crane")
+
+	if game.HasWon() || game.IsOver() {
+		t.Fatal("Game ended after an incorrect guess.")
+	}
+
+	game.MakeGuess("piety")
+
+	if !game.HasWon() || !game.Feedback[1].Solved() {
+		t.Fatal("HasWon() returned false after guessing the answer.")
+	}
+}
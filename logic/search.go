@@ -0,0 +1,313 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Builds the decision tree opening with the opener that solves the answer
+// words in the fewest guesses on average, out of the trees playing only
+// shortlisted guesses. At each node the shortlist is the named strategy's
+// pick, the shortlist-1 other words expected to gain the most information
+// and the possible answer expected to gain the most, and every one is
+// searched in full, with branch and bound cutting off guesses that can't
+// beat the best found so far. The strategy's greedy tree is built first
+// for comparison, and kept if the search does no better. A shortlist of
+// zero skips the search. An error is returned if the context is done
+// before the tree is finished.
+func BuildDecisionTree(ctx context.Context, opener string, strategyName string, shortlist int) (*DecisionTree, error) {
+
+	if shortlist < 0 {
+		return nil, fmt.Errorf("the shortlist can't be negative, got %v", shortlist)
+	}
+
+	greedy, err := BuildGreedyTree(ctx, opener, strategyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	greedy.GreedyExpectedDepth = greedy.ExpectedDepth
+
+	if shortlist == 0 {
+		return greedy, nil
+	}
+
+	strategy, err := StrategyNamed(strategyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := &treeSearch{
+		ctx:       ctx,
+		strategy:  strategy,
+		shortlist: shortlist,
+		best:      make(map[string]searchResult),
+		atLeast:   make(map[string]int),
+	}
+
+	root, depths, err := s.searchOpener(greedy.Opener)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Answers searched on one path are reused on the others, which can
+	// leave out a pick of a strategy that looks at the earlier guesses
+	if float64(depths.total)/float64(len(AnswerWords)) >= greedy.ExpectedDepth {
+
+		greedy.Shortlist = shortlist
+		return greedy, nil
+	}
+
+	return &DecisionTree{
+		Opener:              greedy.Opener,
+		Strategy:            greedy.Strategy,
+		Shortlist:           shortlist,
+		ExpectedDepth:       float64(depths.total) / float64(len(AnswerWords)),
+		MaxDepth:            depths.max,
+		GreedyExpectedDepth: greedy.ExpectedDepth,
+		Root:                root,
+	}, nil
+}
+
+// Searches for the decision tree with the fewest guesses.
+type treeSearch struct {
+	ctx       context.Context
+	strategy  Strategy
+	shortlist int
+	mu        sync.Mutex
+	// The best subtree for each set of answers, keyed by the answers
+	// joined with commas.
+	best map[string]searchResult
+	// The fewest guesses each set of answers that has no subtree in
+	// best is known to need in total.
+	atLeast map[string]int
+}
+
+// A subtree and the guesses it takes to solve its answers, counting
+// its own guess as the first.
+type searchResult struct {
+	node   *DecisionNode
+	depths treeDepths
+}
+
+// Searches the tree under the opener, with the answers left after each
+// of its results searched in parallel.
+func (s *treeSearch) searchOpener(opener string) (*DecisionNode, treeDepths, error) {
+
+	state := SolverState{Candidates: AnswerWords, Context: s.ctx}
+	groups, solved := splitCandidates(opener, AnswerWords)
+	results := make([]searchResult, len(groups))
+
+	err := parallelForContext(s.ctx, len(groups), func(i int) {
+		results[i], _ = s.search(state.after(opener, groups[i]), math.MaxInt)
+	})
+
+	if err != nil {
+		return nil, treeDepths{}, err
+	}
+
+	return joinResults(opener, len(AnswerWords), groups, results), subtreeDepths(len(AnswerWords), solved, results), nil
+}
+
+// Returns the subtree solving the candidates of the state in the fewest
+// guesses in total, if that is fewer than the bound. Returns false if
+// it isn't, or if the context is done.
+func (s *treeSearch) search(state SolverState, bound int) (searchResult, bool) {
+
+	candidates := state.Candidates
+	n := len(candidates)
+
+	// Every answer but one needs a second guess at least
+	if s.ctx.Err() != nil || 2*n-1 >= bound {
+		return searchResult{}, false
+	}
+
+	if n == 1 {
+		return searchResult{&DecisionNode{Guess: candidates[0], Answers: 1}, treeDepths{total: 1, max: 1}}, true
+	}
+
+	key := strings.Join(candidates, ",")
+
+	s.mu.Lock()
+	known, found := s.best[key]
+	atLeast := s.atLeast[key]
+	s.mu.Unlock()
+
+	if found {
+		return known, known.depths.total < bound
+	}
+
+	if atLeast >= bound {
+		return searchResult{}, false
+	}
+
+	// A possible answer that tells every other apart can't be beaten
+	for _, guess := range candidates {
+
+		if groups, _ := splitCandidates(guess, candidates); len(groups) == n-1 {
+			return s.keep(key, s.join(guess, n, groups))
+		}
+	}
+
+	var best searchResult
+	limit := bound
+
+	for _, guess := range s.shortlistFor(state) {
+
+		groups, solved := splitCandidates(guess, candidates)
+
+		// A guess that can't split the answers would be played forever
+		if !solved && len(groups) == 1 {
+			continue
+		}
+
+		// Start from the fewest guesses each group could need, and
+		// put in what it does need once searched, largest first
+		sort.SliceStable(groups, func(i, j int) bool { return len(groups[i].Candidates) > len(groups[j].Candidates) })
+		total := n
+
+		for _, group := range groups {
+			total += 2*len(group.Candidates) - 1
+		}
+
+		results := make([]searchResult, len(groups))
+		beaten := false
+
+		for i, group := range groups {
+
+			if total >= limit {
+
+				beaten = true
+				break
+			}
+
+			fewest := 2*len(group.Candidates) - 1
+			result, ok := s.search(state.after(guess, group), limit-total+fewest)
+
+			if !ok {
+
+				beaten = true
+				break
+			}
+
+			results[i] = result
+			total += result.depths.total - fewest
+		}
+
+		if beaten || total >= limit {
+			continue
+		}
+
+		best = searchResult{joinResults(guess, n, groups, results), subtreeDepths(n, solved, results)}
+		limit = total
+	}
+
+	if s.ctx.Err() != nil {
+		return searchResult{}, false
+	}
+
+	if best.node == nil {
+
+		s.mu.Lock()
+		s.atLeast[key] = max(s.atLeast[key], bound)
+		s.mu.Unlock()
+
+		return searchResult{}, false
+	}
+
+	return s.keep(key, best)
+}
+
+// Remembers the best subtree for the answers with the key and returns it.
+func (s *treeSearch) keep(key string, best searchResult) (searchResult, bool) {
+
+	s.mu.Lock()
+	s.best[key] = best
+	s.mu.Unlock()
+
+	return best, true
+}
+
+// Returns the subtree playing the guess, a possible answer, against
+// answers it tells apart.
+func (s *treeSearch) join(guess string, n int, groups []candidateGroup) searchResult {
+
+	results := make([]searchResult, len(groups))
+
+	for i, group := range groups {
+		results[i] = searchResult{&DecisionNode{Guess: group.Candidates[0], Answers: 1}, treeDepths{total: 1, max: 1}}
+	}
+
+	return searchResult{joinResults(guess, n, groups, results), subtreeDepths(n, true, results)}
+}
+
+// Returns the guesses to search for the state, best first: the strategy's
+// pick, the words expected to gain the most information and the possible
+// answer expected to gain the most.
+func (s *treeSearch) shortlistFor(state SolverState) []string {
+
+	var guesses []string
+	seen := make(map[string]bool)
+
+	add := func(word string) {
+
+		word = strings.ToLower(word)
+
+		if !seen[word] {
+
+			guesses = append(guesses, word)
+			seen[word] = true
+		}
+	}
+
+	add(s.strategy.NextGuess(state))
+
+	if s.shortlist > 1 {
+
+		for _, ranked := range rankGuesses(s.ctx, state.Candidates, s.shortlist-1, nil) {
+			add(ranked.Word)
+		}
+	}
+
+	add(bestCandidate(state.Candidates))
+
+	return guesses
+}
+
+// Returns the node playing the guess against n answers, with the
+// subtree searched for each group under it.
+func joinResults(guess string, n int, groups []candidateGroup, results []searchResult) *DecisionNode {
+
+	node := &DecisionNode{Guess: guess, Answers: n, Next: make(map[string]*DecisionNode, len(groups))}
+
+	for i, group := range groups {
+		node.Next[group.Pattern.String()] = results[i].node
+	}
+
+	return node
+}
+
+// Returns the guesses a node takes to solve its n answers, counting
+// its own guess as the first, from those of the subtrees under it.
+func subtreeDepths(n int, solved bool, results []searchResult) treeDepths {
+
+	depths := treeDepths{total: n}
+
+	if solved {
+		depths.max = 1
+	}
+
+	for _, result := range results {
+		depths.max = max(depths.max, result.depths.max+1)
+		depths.total += result.depths.total
+	}
+
+	return depths
+}
@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBuildDecisionTree(t *testing.T) {
+
+	tree, err := BuildDecisionTree(context.Background(), "crane", "frequency", 3)
+
+	if err != nil {
+		t.Fatalf("BuildDecisionTree returned an error: %v.", err)
+	}
+
+	if greedy := AverageSolveLength("crane", Strategies["frequency"]); math.Abs(tree.GreedyExpectedDepth-greedy) > 1e-9 {
+		t.Fatalf("The tree reported %v guesses for the strategy alone, but it takes %v.", tree.GreedyExpectedDepth, greedy)
+	}
+
+	// The frequency strategy ignores guesses that can't be the answer,
+	// which the search finds a use for
+	if tree.ExpectedDepth >= tree.GreedyExpectedDepth {
+		t.Fatalf("The searched tree expects %v guesses, no better than the strategy's %v.", tree.ExpectedDepth, tree.GreedyExpectedDepth)
+	}
+
+	total, most := 0, 0
+
+	for _, answer := range AnswerWords {
+
+		guesses := playTree(tree, answer)
+
+		if guesses == nil {
+			t.Fatalf("The tree doesn't solve %s.", answer)
+		}
+
+		total += len(guesses)
+		most = max(most, len(guesses))
+	}
+
+	if expected := float64(total) / float64(len(AnswerWords)); math.Abs(tree.ExpectedDepth-expected) > 1e-9 || tree.MaxDepth != most {
+		t.Fatalf("The tree reported depths %v and %v, but took %v and %v.", tree.ExpectedDepth, tree.MaxDepth, expected, most)
+	}
+}
+
+func TestBuildDecisionTreeWithoutSearching(t *testing.T) {
+
+	tree, err := BuildDecisionTree(context.Background(), "crane", "candidates", 0)
+
+	if err != nil {
+		t.Fatalf("BuildDecisionTree returned an error: %v.", err)
+	}
+
+	if tree.Shortlist != 0 || tree.ExpectedDepth != tree.GreedyExpectedDepth {
+		t.Fatalf("The tree searched %v guesses for %v guesses on average, expected the strategy's %v.", tree.Shortlist, tree.ExpectedDepth, tree.GreedyExpectedDepth)
+	}
+
+	if _, err := BuildDecisionTree(context.Background(), "crane", "candidates", -1); err == nil {
+		t.Fatalf("BuildDecisionTree accepted a negative shortlist.")
+	}
+}
+
+func TestSearchFindsFewestGuesses(t *testing.T) {
+
+	// Guessing any of these first leaves three it can't tell apart,
+	// while a word such as thump tells all four apart
+	candidates := []string{"hunch", "munch", "punch", "bunch"}
+	s := &treeSearch{ctx: context.Background(), strategy: Strategies["first"], shortlist: 20, best: make(map[string]searchResult), atLeast: make(map[string]int)}
+
+	result, ok := s.search(SolverState{Candidates: candidates}, math.MaxInt)
+
+	if !ok {
+		t.Fatalf("search found no tree for %v.", candidates)
+	}
+
+	if result.depths.total != 2*len(candidates) {
+		t.Fatalf("search found a tree taking %v guesses in total, expected %v.", result.depths.total, 2*len(candidates))
+	}
+}
+
+func TestBuildDecisionTreeCancelled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := BuildDecisionTree(ctx, "crane", "entropy", 10); !errors.Is(err, context.Canceled) {
+		t.Fatalf("BuildDecisionTree returned %v after being cancelled.", err)
+	}
+}
@@ -0,0 +1,438 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The squares used for each hint in share text.
+var shareSquares = map[int]string{
+	NotInWord:       "⬛",
+	WrongPosition:   "🟨",
+	CorrectPosition: "🟩",
+}
+
+// A finished game summarized without giving away any letters.
+type Share struct {
+	Title      string
+	Results    [][]int
+	Won        bool
+	MaxGuesses int
+	// Left at zero for untimed games.
+	Elapsed time.Duration
+	// The number of hints taken.
+	Hints int
+}
+
+// Returns the share text: a header line with the score,
+// then one row of colored squares per guess.
+func (s Share) String() string {
+
+	score := "X"
+
+	if s.Won {
+		score = fmt.Sprint(len(s.Results))
+	}
+
+	header := fmt.Sprintf("%s %s/%v", s.Title, score, s.MaxGuesses)
+
+	if s.Elapsed > 0 {
+		header += " ⏱ " + FormatDuration(s.Elapsed)
+	}
+
+	if s.Hints > 0 {
+		header += fmt.Sprintf(" 💡%v", s.Hints)
+	}
+
+	return header + "\n\n" + s.Squares()
+}
+
+// Returns the rows of colored squares alone, one per guess.
+func (s Share) Squares() string {
+
+	var rows []string
+
+	for _, result := range s.Results {
+
+		row := ""
+
+		for _, hint := range result {
+
+			row += shareSquares[hint]
+		}
+
+		rows = append(rows, row)
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// Formats a duration as minutes, seconds and tenths, e.g. 1:05.3.
+func FormatDuration(d time.Duration) string {
+
+	tenths := d.Round(100*time.Millisecond) / (100 * time.Millisecond)
+
+	return fmt.Sprintf("%d:%02d.%d", tenths/600, tenths/10%60, tenths%10)
+}
+
+// The characters used for each hint in text patterns.
+var patternChars = map[int]byte{
+	NotInWord:       'b',
+	WrongPosition:   'y',
+	CorrectPosition: 'g',
+}
+
+// Returns the result as a pattern of characters, one per
+// letter: 'g' for green, 'y' for yellow and 'b' for gray.
+func FormatPattern(result []int) string {
+
+	pattern := make([]byte, len(result))
+
+	for i, hint := range result {
+
+		pattern[i] = patternChars[hint]
+	}
+
+	return string(pattern)
+}
+
+// Parses a pattern written by FormatPattern back into a result. Upper
+// case is accepted, as are '.', '-' and 'x' for gray, as other clients
+// write them.
+func ParsePattern(pattern string) ([]int, error) {
+
+	result := make([]int, len(pattern))
+
+	for i, c := range strings.ToLower(pattern) {
+
+		switch c {
+		case 'g':
+			result[i] = CorrectPosition
+		case 'y':
+			result[i] = WrongPosition
+		case 'b', '.', '-', 'x':
+			result[i] = NotInWord
+		default:
+			return nil, fmt.Errorf("unknown color %q in pattern, expected g, y or b", c)
+		}
+	}
+
+	return result, nil
+}
+
+// The hint each square stands for in pasted share grids, including the
+// light theme and high contrast squares other clients share.
+var squareHints = map[rune]int{
+	'⬛': NotInWord,
+	'⬜': NotInWord,
+	'🟨': WrongPosition,
+	'🟦': WrongPosition,
+	'🟩': CorrectPosition,
+	'🟧': CorrectPosition,
+}
+
+// Parses the rows of colored squares in pasted share text back into
+// results. Lines without any squares, such as the header, are skipped.
+// An error is returned if a row has other characters or the wrong
+// number of squares.
+func ParseSquares(text string) ([][]int, error) {
+
+	var results [][]int
+
+	for i, line := range strings.Split(text, "\n") {
+
+		result, err := parseSquareRow(line)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %v %v", i+1, err)
+		}
+
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// Parses a line of colored squares into a result. Nil is
+// returned if the line has no squares at all.
+func parseSquareRow(line string) ([]int, error) {
+
+	var result []int
+	other := false
+
+	for _, r := range strings.TrimSpace(line) {
+
+		hint, ok := squareHints[r]
+
+		switch {
+		case ok:
+			result = append(result, hint)
+		// Emoji may be followed by a variation selector
+		case r == '\uFE0F' || r == ' ':
+		default:
+			other = true
+		}
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	if other {
+		return nil, errors.New("has characters other than squares")
+	}
+
+	if len(result) != WordLength {
+		return nil, fmt.Errorf("has %v squares, expected %v", len(result), WordLength)
+	}
+
+	return result, nil
+}
+
+// A game read back from share text.
+type SharedGame struct {
+	Share
+	// The puzzle number in the header, or zero if there is none.
+	Puzzle int
+	// Whether the header marks the game as played in hard mode.
+	HardMode bool
+
+	// The number of guesses the header scores a win with.
+	score int
+}
+
+// Matches the score in a share header, such as "3/6", "X/6" or "4/6*".
+var shareScore = regexp.MustCompile(`^([0-9]+|X)/([0-9]+)(\*?)$`)
+
+// Matches the puzzle number in a share header, such as "1,234" or "#987".
+var sharePuzzle = regexp.MustCompile(`^#?[0-9][0-9,.]*$`)
+
+// Parses share text, as written by Share and by other Wordle clients,
+// into the games it holds. Each game is a header such as
+// "Wordle 1,234 3/6*" followed by a row of squares per guess. Dark,
+// light and high contrast squares are understood, as are the time and
+// hints Share adds to the header. An error naming the line is returned
+// for anything else, and for games whose rows don't match their score.
+func ParseShares(text string) ([]SharedGame, error) {
+
+	var games []SharedGame
+	var game *SharedGame
+	header := 0
+
+	// Checks the game being read once all of its rows are in
+	finish := func() error {
+
+		if game == nil {
+			return nil
+		}
+
+		if err := game.check(); err != nil {
+			return fmt.Errorf("game on line %v: %w", header, err)
+		}
+
+		games = append(games, *game)
+
+		return nil
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			continue
+		}
+
+		result, err := parseSquareRow(line)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %v %v", i+1, err)
+		}
+
+		if result != nil {
+
+			if game == nil {
+				return nil, fmt.Errorf("line %v: squares come before a header", i+1)
+			}
+
+			game.Results = append(game.Results, result)
+			continue
+		}
+
+		next, err := parseShareHeader(line)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", i+1, err)
+		}
+
+		if err := finish(); err != nil {
+			return nil, err
+		}
+
+		game, header = &next, i+1
+	}
+
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	if len(games) == 0 {
+		return nil, errors.New("no share text found")
+	}
+
+	return games, nil
+}
+
+// Parses share text holding a single game. An error is
+// returned if there is more than one.
+func ParseShare(text string) (SharedGame, error) {
+
+	games, err := ParseShares(text)
+
+	if err != nil {
+		return SharedGame{}, err
+	}
+
+	if len(games) > 1 {
+		return SharedGame{}, fmt.Errorf("expected one game, found %v", len(games))
+	}
+
+	return games[0], nil
+}
+
+// Parses the header line of share text. The score's rows aren't known
+// yet, so whether the game was won is taken from the score alone.
+func parseShareHeader(line string) (SharedGame, error) {
+
+	fields := strings.Fields(line)
+	score := -1
+
+	for i, field := range fields {
+
+		if shareScore.MatchString(field) {
+
+			score = i
+			break
+		}
+	}
+
+	if score < 1 {
+		return SharedGame{}, fmt.Errorf("expected a header such as \"Wordle 1,234 3/6\" or a row of squares, got %q", line)
+	}
+
+	var game SharedGame
+	title := fields[:score]
+
+	if len(title) > 1 && sharePuzzle.MatchString(title[len(title)-1]) {
+
+		number := strings.NewReplacer("#", "", ",", "", ".", "").Replace(title[len(title)-1])
+		game.Puzzle, _ = strconv.Atoi(number)
+		title = title[:len(title)-1]
+	}
+
+	game.Title = strings.Join(title, " ")
+	match := shareScore.FindStringSubmatch(fields[score])
+	game.MaxGuesses, _ = strconv.Atoi(match[2])
+	game.HardMode = match[3] == "*"
+
+	// The rows are counted against the score once they are all read
+	if match[1] != "X" {
+
+		game.Won = true
+		game.score, _ = strconv.Atoi(match[1])
+	}
+
+	extras := fields[score+1:]
+
+	for i := 0; i < len(extras); i++ {
+
+		switch {
+		case extras[i] == "⏱" && i+1 < len(extras):
+			elapsed, err := parseDuration(extras[i+1])
+
+			if err != nil {
+				return SharedGame{}, err
+			}
+
+			game.Elapsed = elapsed
+			i++
+
+		case strings.HasPrefix(extras[i], "💡"):
+			hints, err := strconv.Atoi(strings.TrimPrefix(extras[i], "💡"))
+
+			if err != nil || hints < 0 {
+				return SharedGame{}, fmt.Errorf("invalid number of hints %q", extras[i])
+			}
+
+			game.Hints = hints
+		}
+	}
+
+	return game, nil
+}
+
+// Checks that the rows of a game read from share text match its score.
+func (g *SharedGame) check() error {
+
+	if g.MaxGuesses < 1 {
+		return errors.New("the score allows no guesses")
+	}
+
+	if len(g.Results) == 0 {
+		return errors.New("there are no rows of squares")
+	}
+
+	if len(g.Results) > g.MaxGuesses {
+		return fmt.Errorf("there are %v rows, but only %v guesses are allowed", len(g.Results), g.MaxGuesses)
+	}
+
+	for i, result := range g.Results[:len(g.Results)-1] {
+
+		if isSolvedResult(result) {
+			return fmt.Errorf("row %v is solved, but more rows follow", i+1)
+		}
+	}
+
+	solved := isSolvedResult(g.Results[len(g.Results)-1])
+
+	if g.Won {
+
+		if len(g.Results) != g.score {
+			return fmt.Errorf("the score is %v but there are %v rows", g.score, len(g.Results))
+		}
+
+		if !solved {
+			return errors.New("the game is scored as won, but the last row isn't solved")
+		}
+
+		return nil
+	}
+
+	if solved {
+		return errors.New("the game is scored as lost, but the last row is solved")
+	}
+
+	if len(g.Results) != g.MaxGuesses {
+		return fmt.Errorf("the game is scored as lost after %v rows, but %v guesses are allowed", len(g.Results), g.MaxGuesses)
+	}
+
+	return nil
+}
+
+// Parses a duration written by FormatDuration, such as 1:05.3.
+func parseDuration(text string) (time.Duration, error) {
+
+	var minutes, seconds, tenths int
+
+	if n, err := fmt.Sscanf(text, "%d:%02d.%1d", &minutes, &seconds, &tenths); err != nil || n != 3 || seconds >= 60 {
+		return 0, fmt.Errorf("invalid time %q", text)
+	}
+
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second + time.Duration(tenths)*100*time.Millisecond, nil
+}
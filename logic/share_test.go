@@ -0,0 +1,173 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShareString(t *testing.T) {
+
+	share := Share{
+		Title:      "Godle",
+		Results:    [][]int{{0, 2, 0, 0, 1}, {1, 1, 1, 1, 1}},
+		Won:        true,
+		MaxGuesses: MaxGuesses,
+	}
+	expected := "Godle 2/6\n\n⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩"
+
+	if share.String() != expected {
+		t.Fatalf("String() returned %q, expected %q.", share.String(), expected)
+	}
+
+	// Lost and timed
+	share.Won = false
+	share.Elapsed = 65300 * time.Millisecond
+	expected = "Godle X/6 ⏱ 1:05.3\n\n⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩"
+
+	if share.String() != expected {
+		t.Fatalf("String() returned %q, expected %q.", share.String(), expected)
+	}
+}
+
+func TestShareHints(t *testing.T) {
+
+	share := Share{Title: "Godle", Results: [][]int{{1, 1, 1, 1, 1}}, Won: true, MaxGuesses: 6, Hints: 2}
+
+	if !strings.HasPrefix(share.String(), "Godle 1/6 💡2\n") {
+		t.Fatalf("String() returned %q.", share.String())
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+
+	tests := map[time.Duration]string{
+		0:                       "0:00.0",
+		1234 * time.Millisecond: "0:01.2",
+		61 * time.Second:        "1:01.0",
+		10 * time.Minute:        "10:00.0",
+	}
+
+	for d, expected := range tests {
+
+		if actual := FormatDuration(d); actual != expected {
+			t.Fatalf("FormatDuration(%v) returned %s, expected %s.", d, actual, expected)
+		}
+	}
+}
+
+func TestFormatPattern(t *testing.T) {
+
+	pattern := FormatPattern([]int{NotInWord, CorrectPosition, WrongPosition})
+
+	if pattern != "bgy" {
+		t.Fatalf("FormatPattern returned %s, expected bgy.", pattern)
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+
+	result, err := ParsePattern("BgY.x")
+
+	if err != nil || FormatPattern(result) != "bgybb" {
+		t.Fatalf("ParsePattern returned %v, %v.", result, err)
+	}
+
+	if _, err := ParsePattern("bgq"); err == nil {
+		t.Fatalf("ParsePattern accepted an unknown color.")
+	}
+}
+
+func TestParseSquares(t *testing.T) {
+
+	share := Share{Title: "Godle", Results: [][]int{{0, 2, 0, 0, 1}, {1, 1, 1, 1, 1}}, Won: true, MaxGuesses: 6, Hints: 1}
+	results, err := ParseSquares(share.String())
+
+	if err != nil || len(results) != 2 || FormatPattern(results[0]) != "bybbg" || FormatPattern(results[1]) != "ggggg" {
+		t.Fatalf("ParseSquares returned %v, %v.", results, err)
+	}
+
+	// Light theme and high contrast squares, with variation selectors
+	results, err = ParseSquares("⬜️🟦⬜️⬜️🟧")
+
+	if err != nil || len(results) != 1 || FormatPattern(results[0]) != "bybbg" {
+		t.Fatalf("ParseSquares returned %v, %v.", results, err)
+	}
+
+	for _, text := range []string{"⬛🟨⬛🟩", "⬛🟨⬛⬛🟩 crane"} {
+
+		if _, err := ParseSquares(text); err == nil {
+			t.Fatalf("ParseSquares accepted %q.", text)
+		}
+	}
+}
+
+func TestParseShares(t *testing.T) {
+
+	text := "Wordle 1,234 3/6*\n\n⬛🟨⬛⬛🟩\n🟧🟧⬜🟧🟧\n🟧🟧🟧🟧🟧\n\nWordle #1235 X/6\n" + strings.Repeat("⬛🟦⬛⬛⬛\n", 6)
+	games, err := ParseShares(text)
+
+	if err != nil || len(games) != 2 {
+		t.Fatalf("ParseShares returned %v games, %v.", len(games), err)
+	}
+
+	won, lost := games[0], games[1]
+
+	if won.Title != "Wordle" || won.Puzzle != 1234 || !won.Won || !won.HardMode || won.MaxGuesses != 6 || len(won.Results) != 3 {
+		t.Fatalf("ParseShares read the first game as %+v.", won)
+	}
+
+	if FormatPattern(won.Results[1]) != "ggbgg" {
+		t.Fatalf("ParseShares read the high contrast row as %s.", FormatPattern(won.Results[1]))
+	}
+
+	if lost.Puzzle != 1235 || lost.Won || lost.HardMode || len(lost.Results) != 6 {
+		t.Fatalf("ParseShares read the second game as %+v.", lost)
+	}
+}
+
+func TestParseShareRoundTrip(t *testing.T) {
+
+	share := Share{
+		Title:      "Godle",
+		Results:    [][]int{{0, 2, 0, 0, 1}, {1, 1, 1, 1, 1}},
+		Won:        true,
+		MaxGuesses: 6,
+		Elapsed:    65300 * time.Millisecond,
+		Hints:      2,
+	}
+	game, err := ParseShare(share.String())
+
+	if err != nil {
+		t.Fatalf("ParseShare returned an error: %v.", err)
+	}
+
+	if game.String() != share.String() {
+		t.Fatalf("ParseShare read %q back as %q.", share.String(), game.String())
+	}
+}
+
+func TestParseShareErrors(t *testing.T) {
+
+	tests := map[string]string{
+		"no header":         "⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩",
+		"not share text":    "Hello there",
+		"rows miscounted":   "Wordle 100 3/6\n⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩",
+		"unsolved win":      "Wordle 100 2/6\n⬛🟨⬛⬛🟩\n⬛🟩🟩🟩🟩",
+		"solved loss":       "Wordle 100 X/6\n" + strings.Repeat("⬛🟨⬛⬛🟩\n", 5) + "🟩🟩🟩🟩🟩",
+		"solved early":      "Wordle 100 3/6\n🟩🟩🟩🟩🟩\n⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩",
+		"short loss":        "Wordle 100 X/6\n⬛🟨⬛⬛🟩",
+		"short row":         "Wordle 100 1/6\n🟩🟩🟩🟩",
+		"too many rows":     "Wordle 100 7/6\n" + strings.Repeat("⬛🟨⬛⬛🟩\n", 6) + "🟩🟩🟩🟩🟩",
+		"no rows":           "Wordle 100 3/6",
+		"bad time":          "Godle 1/6 ⏱ soon\n🟩🟩🟩🟩🟩",
+		"two games for one": "Wordle 1 1/6\n🟩🟩🟩🟩🟩\nWordle 2 1/6\n🟩🟩🟩🟩🟩",
+	}
+
+	for name, text := range tests {
+
+		if _, err := ParseShare(text); err == nil {
+			t.Fatalf("ParseShare accepted share text with %s.", name)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// What a strategy knows when choosing the next guess of a game.
+type SolverState struct {
+	Guesses []string
+	Results [][]int
+	// The answer words that fit every result so far. Never empty
+	// when a strategy is asked for a guess.
+	Candidates []string
+	// Stops a strategy searching for a guess once it is done, if it
+	// isn't nil. A strategy stopped early may return any guess, so
+	// the context has to be checked before the guess is used.
+	Context context.Context
+}
+
+// Returns the context of the state, which is never done if none was set.
+func (s SolverState) context() context.Context {
+
+	if s.Context == nil {
+		return context.Background()
+	}
+
+	return s.Context
+}
+
+// Builds the state of a game from its guesses and their results.
+func NewSolverState(guesses []string, results [][]int) (SolverState, error) {
+
+	candidates, err := Candidates(guesses, results)
+
+	if err != nil {
+		return SolverState{}, err
+	}
+
+	return SolverState{Guesses: guesses, Results: results, Candidates: candidates}, nil
+}
+
+// Chooses the next guess of a game from its state.
+type Strategy interface {
+	NextGuess(state SolverState) string
+}
+
+// Lets a plain function be used as a strategy.
+type StrategyFunc func(state SolverState) string
+
+func (f StrategyFunc) NextGuess(state SolverState) string {
+
+	return f(state)
+}
+
+// The strategies that can be chosen by name.
+var Strategies = map[string]Strategy{
+	"entropy":   EntropyStrategy{},
+	"minimax":   MinimaxStrategy{},
+	"frequency": FrequencyStrategy{},
+	"random":    RandomStrategy{},
+	"hard":      HardModeStrategy{},
+	// Guess the answer still possible that is expected to gain the most.
+	"candidates": StrategyFunc(func(state SolverState) string {
+		return bestCandidate(state.Candidates)
+	}),
+	// Guess the first answer still possible.
+	"first": StrategyFunc(func(state SolverState) string {
+		return state.Candidates[0]
+	}),
+}
+
+// Returns the strategy with the given name.
+func StrategyNamed(name string) (Strategy, error) {
+
+	strategy, ok := Strategies[strings.ToLower(name)]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q, expected one of %s", name, strings.Join(StrategyNames(), ", "))
+	}
+
+	return strategy, nil
+}
+
+// Returns the names of the strategies in alphabetical order.
+func StrategyNames() []string {
+
+	var names []string
+
+	for name := range Strategies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Guesses the valid word expected to gain the most information.
+type EntropyStrategy struct{}
+
+func (EntropyStrategy) NextGuess(state SolverState) string {
+
+	return rankGuesses(state.context(), state.Candidates, 1, nil)[0].Word
+}
+
+// Guesses the valid word that leaves the fewest answers possible
+// whatever the result. Ties go to words that could be the answer,
+// then to the fewest answers left on average.
+type MinimaxStrategy struct{}
+
+func (MinimaxStrategy) NextGuess(state SolverState) string {
+
+	m, columns := candidateMatrix(state.Candidates)
+	worst := make([]int, len(m.Guesses))
+	squares := make([]int, len(m.Guesses))
+
+	parallelForContext(state.context(), len(m.Guesses), func(g int) {
+
+		sizes := m.groupSizes(g, columns)
+
+		for _, size := range sizes {
+
+			worst[g] = max(worst[g], size)
+			squares[g] += size * size
+		}
+	})
+
+	isCandidate := wordSet(state.Candidates)
+
+	best := 0
+
+	for g := 1; g < len(m.Guesses); g++ {
+
+		if worst[g] != worst[best] {
+
+			if worst[g] < worst[best] {
+				best = g
+			}
+
+			continue
+		}
+
+		if first, decided := candidateFirst(isCandidate, m.Guesses[g], m.Guesses[best]); decided {
+
+			if first {
+				best = g
+			}
+
+			continue
+		}
+
+		if squares[g] < squares[best] {
+			best = g
+		}
+	}
+
+	return m.Guesses[best]
+}
+
+// Breaks a tie between the guesses a and b in favour of the one that
+// could be the answer, by the set of candidates in lower case. Returns
+// false for decided if both or neither could be.
+func candidateFirst(isCandidate map[string]bool, a string, b string) (first bool, decided bool) {
+
+	aCandidate := isCandidate[strings.ToLower(a)]
+
+	return aCandidate, aCandidate != isCandidate[strings.ToLower(b)]
+}
+
+// Guesses the answer still possible whose letters are the most common
+// among the others, counting each letter once and giving extra weight
+// to letters in the position they are most often found. Quick, but
+// blind to how the results would split the answers.
+type FrequencyStrategy struct{}
+
+func (FrequencyStrategy) NextGuess(state SolverState) string {
+
+	words := make([][]rune, len(state.Candidates))
+	letters := make(map[rune]int)
+	positions := make([]map[rune]int, WordLength)
+
+	for i := range positions {
+		positions[i] = make(map[rune]int)
+	}
+
+	for i, candidate := range state.Candidates {
+
+		words[i] = convertToRunes(candidate)
+		seen := make(map[rune]bool)
+
+		for p, letter := range words[i] {
+
+			if p < WordLength {
+				positions[p][letter]++
+			}
+
+			if !seen[letter] {
+
+				letters[letter]++
+				seen[letter] = true
+			}
+		}
+	}
+
+	best, bestScore := 0, -1
+
+	for i, word := range words {
+
+		score := 0
+		seen := make(map[rune]bool)
+
+		for p, letter := range word {
+
+			if p < WordLength {
+				score += positions[p][letter]
+			}
+
+			if !seen[letter] {
+
+				score += letters[letter]
+				seen[letter] = true
+			}
+		}
+
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	return state.Candidates[best]
+}
+
+// Guesses any answer still possible at random, as a baseline
+// for the other strategies.
+type RandomStrategy struct {
+	// The source of random numbers, or nil for the shared one.
+	Rand *rand.Rand
+}
+
+func (s RandomStrategy) NextGuess(state SolverState) string {
+
+	if s.Rand != nil {
+		return state.Candidates[s.Rand.Intn(len(state.Candidates))]
+	}
+
+	return state.Candidates[rand.Intn(len(state.Candidates))]
+}
+
+// Guesses the word expected to gain the most information out of the
+// words allowed in hard mode, where every letter found has to be used
+// again and every letter in the right position kept there.
+type HardModeStrategy struct{}
+
+func (HardModeStrategy) NextGuess(state SolverState) string {
+
+	k, err := BuildKnowledge(state.Guesses, state.Results)
+
+	// Without the results there is nothing to hold the guess to
+	if err != nil {
+		return rankGuesses(state.context(), state.Candidates, 1, nil)[0].Word
+	}
+
+	return rankGuesses(state.context(), state.Candidates, 1, k.AllowsHardMode)[0].Word
+}
@@ -0,0 +1,129 @@
+package logic
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestStrategiesGuessValidWords(t *testing.T) {
+
+	state, err := NewSolverState([]string{"could"}, [][]int{{NotInWord, CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition}})
+
+	if err != nil {
+		t.Fatalf("NewSolverState returned an error: %v.", err)
+	}
+
+	for _, name := range StrategyNames() {
+
+		guess := Strategies[name].NextGuess(state)
+
+		if !isValidWord(guess) || guess == "could" {
+			t.Fatalf("Strategy %s guessed %q.", name, guess)
+		}
+	}
+}
+
+func TestStrategiesSolveLastCandidate(t *testing.T) {
+
+	state := SolverState{Candidates: []string{"would"}}
+
+	for _, name := range StrategyNames() {
+
+		if guess := Strategies[name].NextGuess(state); guess != "would" {
+			t.Fatalf("Strategy %s guessed %q with only WOULD left.", name, guess)
+		}
+	}
+}
+
+func TestMinimaxStrategy(t *testing.T) {
+
+	candidates := []string{"would", "could", "found", "mould"}
+	guess := MinimaxStrategy{}.NextGuess(SolverState{Candidates: candidates})
+
+	var sizes [PatternCount]int
+
+	for _, candidate := range candidates {
+		sizes[ScorePattern(guess, candidate)]++
+	}
+
+	for _, size := range sizes {
+
+		if size > 1 {
+			t.Fatalf("Minimax guessed %s, which leaves %v answers in the worst case.", guess, size)
+		}
+	}
+}
+
+func TestFrequencyStrategy(t *testing.T) {
+
+	// The letters of AAHED are the rarest among these
+	candidates := []string{"aahed", "crane", "trace", "crate"}
+
+	if guess := (FrequencyStrategy{}).NextGuess(SolverState{Candidates: candidates}); guess == "aahed" {
+		t.Fatalf("Frequency strategy guessed %s.", guess)
+	}
+}
+
+func TestRandomStrategyUsesRand(t *testing.T) {
+
+	state := SolverState{Candidates: AnswerWords}
+	a := RandomStrategy{Rand: rand.New(rand.NewSource(7))}.NextGuess(state)
+	b := RandomStrategy{Rand: rand.New(rand.NewSource(7))}.NextGuess(state)
+
+	if a != b {
+		t.Fatalf("Random strategy guessed %s and %s from the same seed.", a, b)
+	}
+}
+
+func TestHardModeStrategy(t *testing.T) {
+
+	guesses := []string{"crane"}
+	results := [][]int{{NotInWord, CorrectPosition, WrongPosition, NotInWord, NotInWord}}
+	state, err := NewSolverState(guesses, results)
+
+	if err != nil {
+		t.Fatalf("NewSolverState returned an error: %v.", err)
+	}
+
+	k, _ := BuildKnowledge(guesses, results)
+
+	if guess := (HardModeStrategy{}).NextGuess(state); !k.AllowsHardMode(guess) {
+		t.Fatalf("Hard mode strategy guessed %s, which hard mode doesn't allow.", guess)
+	}
+}
+
+func TestStrategyNamed(t *testing.T) {
+
+	if strategy, err := StrategyNamed("Entropy"); err != nil || strategy != Strategies["entropy"] {
+		t.Fatalf("StrategyNamed(\"Entropy\") returned %v, %v.", strategy, err)
+	}
+
+	if _, err := StrategyNamed("psychic"); err == nil {
+		t.Fatalf("StrategyNamed accepted an unknown strategy.")
+	}
+}
+
+func TestStrategiesStopWhenCancelled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, name := range []string{"entropy", "minimax", "hard"} {
+
+		strategy := Strategies[name]
+
+		start := time.Now()
+		strategy.NextGuess(SolverState{Candidates: AnswerWords})
+		full := time.Since(start)
+
+		start = time.Now()
+		strategy.NextGuess(SolverState{Candidates: AnswerWords, Context: ctx})
+		stopped := time.Since(start)
+
+		if stopped > full/2 {
+			t.Fatalf("The %s strategy took %v when cancelled, and %v to search in full.", name, stopped, full)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package logic
+
+import "time"
+
+// A source of the current time. Times read from the system clock
+// carry a monotonic reading, so durations measured between them
+// are unaffected by changes to the wall clock.
+type Clock interface {
+	Now() time.Time
+	// Returns a channel that receives the time once d has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// The clock used outside of tests.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+
+	return time.Now()
+}
+
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+
+	return time.After(d)
+}
+
+// Tracks the total time taken on a puzzle and the time taken for each guess.
+type Stopwatch struct {
+	Splits []time.Duration
+	clock  Clock
+	start  time.Time
+	lap    time.Time
+}
+
+// Creates a stopwatch on the given clock and starts it.
+func NewStopwatch(clock Clock) *Stopwatch {
+
+	now := clock.Now()
+
+	return &Stopwatch{clock: clock, start: now, lap: now}
+}
+
+// Records and returns the time taken since the previous lap.
+func (s *Stopwatch) Lap() time.Duration {
+
+	now := s.clock.Now()
+	split := now.Sub(s.lap)
+
+	s.lap = now
+	s.Splits = append(s.Splits, split)
+
+	return split
+}
+
+// Returns the time since the stopwatch was started.
+func (s *Stopwatch) Elapsed() time.Duration {
+
+	return s.clock.Now().Sub(s.start)
+}
+
+// Returns the time since the previous lap.
+func (s *Stopwatch) Current() time.Duration {
+
+	return s.clock.Now().Sub(s.lap)
+}
+
+// A fixed time budget for a whole puzzle, or for each guess.
+type Countdown struct {
+	Budget   time.Duration
+	PerGuess bool
+	*Stopwatch
+}
+
+// Creates a countdown on the given clock and starts it.
+func NewCountdown(clock Clock, budget time.Duration, perGuess bool) *Countdown {
+
+	return &Countdown{
+		Budget:    budget,
+		PerGuess:  perGuess,
+		Stopwatch: NewStopwatch(clock),
+	}
+}
+
+// Returns the time left in the budget, which is never negative.
+func (c *Countdown) Remaining() time.Duration {
+
+	used := c.Elapsed()
+
+	if c.PerGuess {
+		used = c.Current()
+	}
+
+	if used >= c.Budget {
+		return 0
+	}
+
+	return c.Budget - used
+}
+
+// Returns true if the budget has run out.
+func (c *Countdown) Expired() bool {
+
+	return c.Remaining() == 0
+}
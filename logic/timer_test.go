@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"testing"
+	"time"
+)
+
+// A clock that only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+
+	return f.now
+}
+
+// Timers never fire, since the clock doesn't move on its own.
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+
+	return nil
+}
+
+func (f *fakeClock) advance(d time.Duration) {
+
+	f.now = f.now.Add(d)
+}
+
+func TestStopwatch(t *testing.T) {
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	watch := NewStopwatch(clock)
+
+	clock.advance(3 * time.Second)
+
+	if watch.Current() != 3*time.Second {
+		t.Fatalf("Current() returned %v, expected 3s.", watch.Current())
+	}
+
+	if split := watch.Lap(); split != 3*time.Second {
+		t.Fatalf("Lap() returned %v, expected 3s.", split)
+	}
+
+	clock.advance(2 * time.Second)
+	watch.Lap()
+
+	expected := []time.Duration{3 * time.Second, 2 * time.Second}
+
+	if len(watch.Splits) != 2 || watch.Splits[0] != expected[0] || watch.Splits[1] != expected[1] {
+		t.Fatalf("Splits were %v, expected %v.", watch.Splits, expected)
+	}
+
+	if watch.Elapsed() != 5*time.Second {
+		t.Fatalf("Elapsed() returned %v, expected 5s.", watch.Elapsed())
+	}
+
+	if watch.Current() != 0 {
+		t.Fatalf("Current() returned %v right after a lap.", watch.Current())
+	}
+}
+
+func TestCountdownPerPuzzle(t *testing.T) {
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	countdown := NewCountdown(clock, 10*time.Second, false)
+
+	clock.advance(6 * time.Second)
+	countdown.Lap()
+	clock.advance(3 * time.Second)
+
+	if countdown.Remaining() != time.Second || countdown.Expired() {
+		t.Fatalf("Remaining() returned %v, expected 1s.", countdown.Remaining())
+	}
+
+	clock.advance(5 * time.Second)
+
+	if countdown.Remaining() != 0 || !countdown.Expired() {
+		t.Fatalf("Remaining() returned %v after the budget ran out.", countdown.Remaining())
+	}
+}
+
+func TestCountdownPerGuess(t *testing.T) {
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	countdown := NewCountdown(clock, 10*time.Second, true)
+
+	// Each lap gets a fresh budget
+	for i := 0; i < 3; i++ {
+
+		clock.advance(8 * time.Second)
+
+		if countdown.Expired() {
+			t.Fatalf("Countdown expired on guess %v with time left.", i+1)
+		}
+
+		countdown.Lap()
+	}
+
+	clock.advance(11 * time.Second)
+
+	if !countdown.Expired() {
+		t.Fatal("Countdown did not expire after the guess budget ran out.")
+	}
+}
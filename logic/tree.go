@@ -0,0 +1,432 @@
+package logic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The pattern of a guess that is the answer.
+var solvedPattern = EncodePattern([]int{CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition, CorrectPosition})
+
+// A guess in a decision tree and what to play after each result it can get.
+type DecisionNode struct {
+	Guess string `json:"guess"`
+	// The number of answers still possible when the guess is played.
+	// It is zero in trees read from the text format.
+	Answers int `json:"answers,omitempty"`
+	// The node to play next after each result other than the guess
+	// being the answer, keyed by the result written as FormatPattern does.
+	Next map[string]*DecisionNode `json:"next,omitempty"`
+}
+
+// Every guess to play to solve any answer word, starting from an opener.
+// Later guesses are either a strategy's picks, making the tree greedy,
+// or searched for out of a shortlist of guesses at each node.
+type DecisionTree struct {
+	Opener   string `json:"opener"`
+	Strategy string `json:"strategy,omitempty"`
+	// The number of guesses searched at each node, or zero if the
+	// tree is the strategy's alone.
+	Shortlist int `json:"shortlist,omitempty"`
+	// The mean and most guesses taken to solve an answer word.
+	// They are zero in trees read from the text format.
+	ExpectedDepth float64 `json:"expectedDepth,omitempty"`
+	MaxDepth      int     `json:"maxDepth,omitempty"`
+	// The mean guesses taken by the strategy's greedy tree, to compare
+	// a searched tree with. It is zero if it wasn't worked out.
+	GreedyExpectedDepth float64       `json:"greedyExpectedDepth,omitempty"`
+	Root                *DecisionNode `json:"root"`
+}
+
+// Builds the decision tree opening with the opener and choosing each
+// later guess with the named strategy, without searching for a better
+// guess than the strategy's. Branches at every depth are built in
+// parallel while there are CPUs free. An error is returned if the
+// context is done before the tree is finished.
+func BuildGreedyTree(ctx context.Context, opener string, strategyName string) (*DecisionTree, error) {
+
+	opener = strings.ToLower(opener)
+
+	if !isValidWord(opener) {
+		return nil, fmt.Errorf("%q is not a valid word", opener)
+	}
+
+	strategy, err := StrategyNamed(strategyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b := treeBuilder{ctx: ctx, strategy: strategy, workers: make(chan struct{}, runtime.NumCPU())}
+	root, depths, err := b.build(SolverState{Candidates: AnswerWords}, opener, 1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DecisionTree{
+		Opener:        opener,
+		Strategy:      strings.ToLower(strategyName),
+		ExpectedDepth: float64(depths.total) / float64(len(AnswerWords)),
+		MaxDepth:      depths.max,
+		Root:          root,
+	}, nil
+}
+
+// Builds the nodes of a decision tree.
+type treeBuilder struct {
+	ctx      context.Context
+	strategy Strategy
+	// Holds a place for each branch being built in parallel, shared
+	// by every level of the tree.
+	workers chan struct{}
+}
+
+// The depths the answers under a node are solved at.
+type treeDepths struct {
+	total int
+	max   int
+}
+
+// The answers left after a result of a guess.
+type candidateGroup struct {
+	Pattern    Pattern
+	Candidates []string
+}
+
+// Splits the candidates by the result the guess gets against each, in
+// the order the results first come up. Returns true for solved if the
+// guess is one of the candidates.
+func splitCandidates(guess string, candidates []string) (groups []candidateGroup, solved bool) {
+
+	index := make(map[Pattern]int)
+
+	for _, candidate := range candidates {
+
+		pattern := ScorePattern(guess, candidate)
+
+		if pattern == solvedPattern {
+
+			solved = true
+			continue
+		}
+
+		i, ok := index[pattern]
+
+		if !ok {
+
+			i = len(groups)
+			index[pattern] = i
+			groups = append(groups, candidateGroup{Pattern: pattern})
+		}
+
+		groups[i].Candidates = append(groups[i].Candidates, candidate)
+	}
+
+	return groups, solved
+}
+
+// Returns the state after the guess got the result leading to the group.
+func (s SolverState) after(guess string, group candidateGroup) SolverState {
+
+	return SolverState{
+		Guesses:    append(append([]string(nil), s.Guesses...), guess),
+		Results:    append(append([][]int(nil), s.Results...), group.Pattern.Result()),
+		Candidates: group.Candidates,
+		Context:    s.Context,
+	}
+}
+
+// Builds the node playing the guess at the given depth against the
+// candidates of the state, and everything under it.
+func (b *treeBuilder) build(state SolverState, guess string, depth int) (*DecisionNode, treeDepths, error) {
+
+	if err := b.ctx.Err(); err != nil {
+		return nil, treeDepths{}, err
+	}
+
+	if depth > maxSolveGuesses {
+		return nil, treeDepths{}, fmt.Errorf("the strategy takes over %v guesses to solve some answers", maxSolveGuesses)
+	}
+
+	node := &DecisionNode{Guess: strings.ToLower(guess), Answers: len(state.Candidates)}
+	var depths treeDepths
+
+	groups, solved := splitCandidates(guess, state.Candidates)
+
+	if solved {
+
+		depths.total += depth
+		depths.max = depth
+	}
+
+	if len(groups) == 0 {
+		return node, depths, nil
+	}
+
+	node.Next = make(map[string]*DecisionNode, len(groups))
+	children := make([]*DecisionNode, len(groups))
+	childDepths := make([]treeDepths, len(groups))
+	errs := make([]error, len(groups))
+
+	buildChild := func(i int) {
+
+		next := state.after(guess, groups[i])
+		next.Context = b.ctx
+		nextGuess := b.strategy.NextGuess(next)
+
+		// The strategy may have been stopped part way through its search
+		if err := b.ctx.Err(); err != nil {
+
+			errs[i] = err
+			return
+		}
+
+		// A guess that can't split the answers left would be played forever
+		if len(next.Candidates) == len(state.Candidates) && !containsWord(next.Candidates, nextGuess) {
+			nextGuess = next.Candidates[0]
+		}
+
+		children[i], childDepths[i], errs[i] = b.build(next, nextGuess, depth+1)
+	}
+
+	// A branch is handed to another goroutine whenever a worker is free,
+	// at any depth, and is otherwise built here. Never waiting for a
+	// worker means a branch can't hold one while waiting on its own.
+	var wg sync.WaitGroup
+
+	for i := range groups {
+
+		select {
+
+		case b.workers <- struct{}{}:
+			wg.Add(1)
+
+			go func(i int) {
+
+				defer wg.Done()
+				defer func() { <-b.workers }()
+
+				buildChild(i)
+			}(i)
+
+		default:
+			buildChild(i)
+		}
+	}
+
+	wg.Wait()
+
+	for i, group := range groups {
+
+		if errs[i] != nil {
+			return nil, treeDepths{}, errs[i]
+		}
+
+		node.Next[group.Pattern.String()] = children[i]
+		depths.total += childDepths[i].total
+		depths.max = max(depths.max, childDepths[i].max)
+	}
+
+	return node, depths, nil
+}
+
+// Returns the guess the tree plays after the guesses and their results,
+// or false if the guesses aren't the ones the tree played.
+func (t *DecisionTree) Next(guesses []string, results [][]int) (string, bool) {
+
+	if len(guesses) != len(results) {
+		return "", false
+	}
+
+	node := t.Root
+
+	for i, guess := range guesses {
+
+		if node == nil || !strings.EqualFold(node.Guess, guess) {
+			return "", false
+		}
+
+		node = node.Next[FormatPattern(results[i])]
+	}
+
+	if node == nil {
+		return "", false
+	}
+
+	return node.Guess, true
+}
+
+// Writes the tree in the compact text format: a line for every node with
+// the results leading to it, separated by slashes, then its guess. The
+// opener's line has "-" for the results, and parents come before their
+// children, for example:
+//
+//	# godle decision tree opening with crane
+//	- crane
+//	bbbbb sloth
+//	bbbbb/bbgbb fight
+func (t *DecisionTree) WriteText(w io.Writer) error {
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# godle decision tree opening with %s", t.Opener)
+
+	if t.Strategy != "" {
+		fmt.Fprintf(bw, ", %s strategy", t.Strategy)
+	}
+
+	if t.Shortlist > 0 {
+		fmt.Fprintf(bw, ", searching %v guesses", t.Shortlist)
+	}
+
+	fmt.Fprintln(bw)
+
+	if t.Root != nil {
+		writeTextNode(bw, "-", t.Root)
+	}
+
+	return bw.Flush()
+}
+
+// Writes the line of the node reached by the path, then the lines under it.
+func writeTextNode(w io.Writer, path string, node *DecisionNode) {
+
+	fmt.Fprintf(w, "%s %s\n", path, node.Guess)
+
+	var patterns []string
+
+	for pattern := range node.Next {
+		patterns = append(patterns, pattern)
+	}
+
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+
+		childPath := pattern
+
+		if path != "-" {
+			childPath = path + "/" + pattern
+		}
+
+		writeTextNode(w, childPath, node.Next[pattern])
+	}
+}
+
+// Reads a tree written as JSON or in the compact text format.
+func ReadDecisionTree(r io.Reader) (*DecisionTree, error) {
+
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+
+		var tree DecisionTree
+
+		if err := json.Unmarshal(trimmed, &tree); err != nil {
+			return nil, err
+		}
+
+		if tree.Root == nil {
+			return nil, errors.New("decision tree has no opener")
+		}
+
+		return &tree, nil
+	}
+
+	return readTextTree(data)
+}
+
+// Reads a tree written by WriteText. Blank lines and lines starting
+// with '#' are skipped.
+func readTextTree(data []byte) (*DecisionTree, error) {
+
+	tree := &DecisionTree{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %v: expected results and a guess", line)
+		}
+
+		path, guess := fields[0], strings.ToLower(fields[1])
+
+		if path == "-" {
+
+			if tree.Root != nil {
+				return nil, fmt.Errorf("line %v: the opener is given twice", line)
+			}
+
+			tree.Opener = guess
+			tree.Root = &DecisionNode{Guess: guess}
+			continue
+		}
+
+		if tree.Root == nil {
+			return nil, fmt.Errorf("line %v: the opener must come first", line)
+		}
+
+		patterns := strings.Split(path, "/")
+		parent := tree.Root
+
+		for i, pattern := range patterns {
+
+			result, err := ParsePattern(pattern)
+
+			if err != nil || len(result) != WordLength {
+				return nil, fmt.Errorf("line %v: invalid results %q", line, pattern)
+			}
+
+			key := FormatPattern(result)
+
+			if i == len(patterns)-1 {
+
+				if parent.Next == nil {
+					parent.Next = make(map[string]*DecisionNode)
+				}
+
+				if parent.Next[key] != nil {
+					return nil, fmt.Errorf("line %v: a guess for %q is already given", line, path)
+				}
+
+				parent.Next[key] = &DecisionNode{Guess: guess}
+				break
+			}
+
+			if parent = parent.Next[key]; parent == nil {
+				return nil, fmt.Errorf("line %v: no guess is given for %q", line, strings.Join(patterns[:i+1], "/"))
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if tree.Root == nil {
+		return nil, errors.New("decision tree has no opener")
+	}
+
+	return tree, nil
+}
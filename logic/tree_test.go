@@ -0,0 +1,143 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// Plays the tree against the answer, returning the guesses it took.
+func playTree(tree *DecisionTree, answer string) []string {
+
+	var guesses []string
+	var results [][]int
+
+	for len(guesses) < maxSolveGuesses {
+
+		guess, ok := tree.Next(guesses, results)
+
+		if !ok {
+			return nil
+		}
+
+		guesses = append(guesses, guess)
+
+		if strings.EqualFold(guess, answer) {
+			return guesses
+		}
+
+		results = append(results, ScorePattern(guess, answer).Result())
+	}
+
+	return nil
+}
+
+func TestDecisionTreeSolvesEveryAnswer(t *testing.T) {
+
+	tree, err := BuildGreedyTree(context.Background(), "crane", "candidates")
+
+	if err != nil {
+		t.Fatalf("BuildGreedyTree returned an error: %v.", err)
+	}
+
+	total, most := 0, 0
+
+	for _, answer := range AnswerWords {
+
+		guesses := playTree(tree, answer)
+
+		if guesses == nil {
+			t.Fatalf("The tree doesn't solve %s.", answer)
+		}
+
+		total += len(guesses)
+		most = max(most, len(guesses))
+	}
+
+	if expected := float64(total) / float64(len(AnswerWords)); math.Abs(tree.ExpectedDepth-expected) > 1e-9 || tree.MaxDepth != most {
+		t.Fatalf("The tree reported depths %v and %v, but took %v and %v.", tree.ExpectedDepth, tree.MaxDepth, expected, most)
+	}
+
+	if average := AverageSolveLength("crane", Strategies["candidates"]); math.Abs(tree.ExpectedDepth-average) > 1e-9 {
+		t.Fatalf("The tree expects %v guesses, but solving takes %v.", tree.ExpectedDepth, average)
+	}
+
+	// Both formats must play the same guesses once read back
+	var text, jsonData bytes.Buffer
+
+	if err := tree.WriteText(&text); err != nil {
+		t.Fatalf("WriteText returned an error: %v.", err)
+	}
+
+	if err := json.NewEncoder(&jsonData).Encode(tree); err != nil {
+		t.Fatalf("Encoding the tree returned an error: %v.", err)
+	}
+
+	for _, data := range []*bytes.Buffer{&text, &jsonData} {
+
+		read, err := ReadDecisionTree(data)
+
+		if err != nil {
+			t.Fatalf("ReadDecisionTree returned an error: %v.", err)
+		}
+
+		for _, answer := range AnswerWords[:100] {
+
+			if got, want := playTree(read, answer), playTree(tree, answer); strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Fatalf("The tree read back played %v against %s, expected %v.", got, answer, want)
+			}
+		}
+	}
+}
+
+func TestDecisionTreeCancelled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := BuildGreedyTree(ctx, "crane", "entropy"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("BuildGreedyTree returned %v after being cancelled.", err)
+	}
+}
+
+func TestReadTextTree(t *testing.T) {
+
+	tree, err := ReadDecisionTree(strings.NewReader("# comment\n- crane\nbbbbb sloth\nbbbbb/..G.. fight\n"))
+
+	if err != nil {
+		t.Fatalf("ReadDecisionTree returned an error: %v.", err)
+	}
+
+	gray := make([]int, WordLength)
+	results := [][]int{gray, {NotInWord, NotInWord, CorrectPosition, NotInWord, NotInWord}}
+
+	if guess, ok := tree.Next([]string{"crane", "sloth"}, results); !ok || guess != "fight" {
+		t.Fatalf("Next returned %s, %v, expected fight.", guess, ok)
+	}
+
+	if _, ok := tree.Next([]string{"slate"}, results[:1]); ok {
+		t.Fatalf("Next followed a guess the tree doesn't play.")
+	}
+
+	for _, text := range []string{"bbbbb sloth\n- crane\n", "- crane\nbbbbb/bbbbb fight\n", "- crane\nbbb sloth\n", ""} {
+
+		if _, err := ReadDecisionTree(strings.NewReader(text)); err == nil {
+			t.Fatalf("ReadDecisionTree accepted %q.", text)
+		}
+	}
+}
+
+func TestReadTextTreeRepeatedResults(t *testing.T) {
+
+	// The same results written two ways are still the same branch
+	text := "- crane\nbbbbb sloth\nbbbbb/..G.. fight\n..... doubt\n"
+	_, err := ReadDecisionTree(strings.NewReader(text))
+
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Fatalf("ReadDecisionTree returned %v for results given twice, expected an error on line 4.", err)
+	}
+}
@@ -1,200 +1,72 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/Dannflower/godle/cli"
+	"github.com/Dannflower/godle/history"
 	"github.com/Dannflower/godle/logic"
+	"github.com/Dannflower/godle/stats"
 
 	"github.com/fatih/color"
+	"golang.org/x/term"
 )
 
-var scanner *bufio.Scanner
-
-func init() {
-	scanner = bufio.NewScanner(os.Stdin)
-}
-
 func main() {
 
-	printTitle()
-	printMenu()
-	handleMenuInput()
-}
-
-func printTitle() {
+	format := flag.String("format", "ansi", "output format: ansi, plain, json or markdown")
+	strategyName := flag.String("strategy", "", "strategy picking the word suggested by /hint word: "+strings.Join(logic.StrategyNames(), ", "))
+	flag.Parse()
 
-	title := "  _____           _ _       \n" +
-		" / ____|         | | |      \n" +
-		"| |  __  ___   __| | | ___  \n" +
-		"| | |_ |/ _ \\ / _` | |/ _ \\ \n" +
-		"| |__| | (_) | (_| | |  __/ \n" +
-		" \\_____|\\___/ \\__,_|_|\\___| \n"
+	if flag.NArg() > 0 {
 
-	fmt.Println(title)
-}
-
-func printMenu() {
-
-	fmt.Println("Options\t\tKey")
-	fmt.Println("-------\t\t---")
-	fmt.Println("Play\t\t p")
-	fmt.Println("Rules\t\t r")
-	fmt.Println("Quit\t\t q")
-	fmt.Println()
-}
-
-// Handles menu option selections
-func handleMenuInput() {
-
-	for {
-
-		fmt.Print("Command: ")
-		scanner.Scan()
-
-		switch scanner.Text() {
-		case "p":
-			// Start new game
-			play()
-			printMenu()
-		case "r":
-			// Display rules, loop back to start of input
-			printRules()
-			printMenu()
-		case "q":
-			fmt.Println("Thanks for playing!")
-			os.Exit(0)
-		default:
-			fmt.Println("Invalid option.")
-			// loop back to start of input
-		}
+		runCommand(flag.Arg(0), flag.Args()[1:])
+		return
 	}
-}
-
-func printRules() {
-	fmt.Println("Attempt to guess a randomly selected 5-letter word.")
-	fmt.Printf("You get %v guesses to get the right word.\n", logic.MaxGuesses)
-	fmt.Println("After guessing your guess will be displayed with color coding indicating the following:")
-	color.HiBlack("Gray - The letter is not in the word.")
-	color.Yellow("Yellow - The letter is in the word but is in the wrong position.")
-	color.Green("Green - The letter is in the word and in the right position.")
-	fmt.Println("If all guesses are exhausted, the answer will be revealed. Good luck word nerd!")
-	fmt.Println("Hit enter to return to the menu.")
-	scanner.Scan()
-}
-
-func handleWin(guesses []string) {
-
-	fmt.Println("You got it!")
-	fmt.Printf("Guesses: %v/%v\n", len(guesses), logic.MaxGuesses)
-	fmt.Println("Hit enter to return to the menu.")
-	scanner.Scan()
-}
-
-// Start the core game loop.
-func play() {
-
-	logic.NewGame()
-
-	fmt.Println("Guess the word!")
-
-	for len(logic.Guesses) < logic.MaxGuesses {
 
-		fmt.Print("Guess: ")
-		scanner.Scan()
+	renderer, err := cli.RendererNamed(*format)
 
-		guess := scanner.Text()
-		err := logic.MakeGuess(guess)
+	if err != nil {
 
-		if err != nil {
-
-			fmt.Printf("Invalid guess: %v.\n", err)
-
-		} else {
-
-			printGuessResult()
-			printAvailableLetters(logic.UsedLetters)
-
-			// Player has won!
-			if logic.HasWon(guess) {
-
-				handleWin(logic.Guesses)
-				return
-			}
-		}
+		fmt.Fprintf(os.Stderr, "godle: %v\n", err)
+		os.Exit(2)
 	}
 
-	fmt.Printf("Nice try! The word was '%s.'\n", logic.Answer)
-	fmt.Println("Hit enter to return to the menu.")
-	scanner.Scan()
-}
-
-// Prints the results of the last guess and all previous guesses
-// with runes color coded depending on whether they are in the word,
-// not in the word, or in the word but the wrong location.
-func printGuessResult() {
-
-	for i, guess := range logic.Guesses {
+	session := cli.New(os.Stdin, color.Output, renderer)
 
-		capGuess := strings.ToUpper(guess)
-		colorResult := ""
+	if *strategyName != "" {
 
-		for j, r := range capGuess {
+		if session.Strategy, err = logic.StrategyNamed(*strategyName); err != nil {
 
-			colorResult += addHintColor(string(r), logic.Results[i][j])
+			fmt.Fprintf(os.Stderr, "godle: %v\n", err)
+			os.Exit(2)
 		}
-
-		fmt.Println(colorResult)
 	}
-}
 
-// Prints out the complete list of letters with any
-// used in previous guesses printed in gray.
-func printAvailableLetters(usedLetters map[rune]int) {
+	session.StatsPath, _ = stats.DefaultPath()
+	session.HistoryPath, _ = history.DefaultPath()
+	// The live timer is only drawn on a color terminal
+	_, ansi := renderer.(cli.ANSIRenderer)
+	session.Terminal = ansi && !color.NoColor
 
-	letters := []rune{
-		'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M',
-		'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z'}
+	// Secret words are read without echo whenever they're typed at a terminal
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
 
-	line := ""
+		session.ReadHidden = func() (string, error) {
 
-	for i, letter := range letters {
+			line, err := term.ReadPassword(fd)
+			fmt.Println()
 
-		colorLetter := string(letter)
-
-		// Add hint color if the letter has been used
-		if _, ok := usedLetters[letter]; ok {
-			colorLetter = addHintColor(colorLetter, usedLetters[letter])
-		}
-
-		line += colorLetter + " "
-
-		if i == 12 || i == 25 {
-
-			fmt.Println(line)
-			line = ""
+			return string(line), err
 		}
 	}
-}
-
-// Returns the ANSI coded version of the string
-// colored appropriately for the given hint.
-func addHintColor(str string, hint int) string {
-
-	switch hint {
-
-	case logic.NotInWord:
-		return color.HiBlackString(str)
-
-	case logic.WrongPosition:
-		return color.YellowString(str)
 
-	case logic.CorrectPosition:
-		return color.GreenString(str)
+	if err := session.Run(); err != nil {
 
-	default:
-		return str
+		fmt.Fprintf(os.Stderr, "godle: %v\n", err)
+		os.Exit(1)
 	}
 }
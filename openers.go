@@ -0,0 +1,159 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Opener scores saved to disk, with the key of what they were worked out from.
+type openersCache struct {
+	Key    string
+	Scores []logic.OpenerScore
+}
+
+// Ranks every valid word as a first guess.
+func openersCommand(args []string) error {
+
+	flags := flag.NewFlagSet("openers", flag.ExitOnError)
+	column := flags.String("sort", "entropy", "column to sort by: word, expected, entropy, worst or solve")
+	limit := flags.Int("limit", 20, "number of words to show, or 0 for all")
+	solve := flags.Int("solve", 10, "work out the average solve length for this many of the openers with the most entropy")
+	strategy := flags.String("strategy", "candidates", "strategy for the guesses after the opener: "+strings.Join(logic.StrategyNames(), ", "))
+	cachePath := flags.String("cache", defaultOpenersCache(), "file to cache the scores in, or empty for no cache")
+	refresh := flags.Bool("refresh", false, "work the scores out again even if they are cached")
+	flags.Parse(args)
+
+	solveStrategy, err := logic.StrategyNamed(*strategy)
+
+	if err != nil {
+		return err
+	}
+
+	key := openersKey(*strategy, *solve)
+	var scores []logic.OpenerScore
+
+	if *cachePath != "" && !*refresh {
+		scores = loadOpeners(*cachePath, key)
+	}
+
+	if scores == nil {
+
+		// Keep the patterns next to the scores so the next run can skip scoring words
+		if *cachePath != "" {
+
+			if err := logic.UseMatrixCache(filepath.Join(filepath.Dir(*cachePath), "patterns.bin")); err != nil {
+				fmt.Fprintf(os.Stderr, "Could not cache the patterns: %v\n", err)
+			}
+		}
+
+		scores = logic.ScoreOpeners()
+		logic.SortOpeners(scores, "entropy")
+
+		for i := 0; i < *solve && i < len(scores); i++ {
+
+			fmt.Fprintf(os.Stderr, "Solving with %s (%v/%v)\n", scores[i].Word, i+1, *solve)
+			scores[i].AverageSolve = logic.AverageSolveLength(scores[i].Word, solveStrategy)
+		}
+
+		if *cachePath != "" {
+
+			if err := saveOpeners(*cachePath, openersCache{Key: key, Scores: scores}); err != nil {
+				fmt.Fprintf(os.Stderr, "Could not cache the scores: %v\n", err)
+			}
+		}
+	}
+
+	if !logic.SortOpeners(scores, *column) {
+		return fmt.Errorf("unknown column %q", *column)
+	}
+
+	if *limit > 0 && *limit < len(scores) {
+		scores = scores[:*limit]
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "WORD\tEXPECTED\tENTROPY\tWORST\tSOLVE\t")
+
+	for _, score := range scores {
+
+		solveText := "-"
+
+		if score.AverageSolve > 0 {
+			solveText = fmt.Sprintf("%.3f", score.AverageSolve)
+		}
+
+		fmt.Fprintf(table, "%s\t%.2f\t%.3f\t%v\t%s\t\n", score.Word, score.Expected, score.Entropy, score.Worst, solveText)
+	}
+
+	return table.Flush()
+}
+
+// Returns the path of the openers cache in the user's cache directory,
+// or an empty path if there is no cache directory.
+func defaultOpenersCache() string {
+
+	dir, err := os.UserCacheDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "godle", "openers.json")
+}
+
+// Returns a key that changes whenever the word lists or the
+// options the scores are worked out with change.
+func openersKey(strategy string, solve int) string {
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%v\n", strategy, solve)
+	hash.Write([]byte(strings.Join(logic.AnswerWords, ",")))
+	hash.Write([]byte{'\n'})
+	hash.Write([]byte(strings.Join(logic.ValidWords, ",")))
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Loads the cached scores at path if they were worked out
+// with the key, otherwise returns nil.
+func loadOpeners(path string, key string) []logic.OpenerScore {
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil
+	}
+
+	var cache openersCache
+
+	if json.Unmarshal(data, &cache) != nil || cache.Key != key {
+		return nil
+	}
+
+	return cache.Scores
+}
+
+// Saves the scores to path, creating its directory if needed.
+func saveOpeners(path string, cache openersCache) error {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cache)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
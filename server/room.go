@@ -0,0 +1,280 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// The most lines queued for a client before it is disconnected.
+const clientQueue = 64
+
+// A connected player. Lines sent to a client are written by their own
+// goroutine, and a client that falls a whole queue behind is dropped,
+// so a slow reader never stalls a room.
+type client struct {
+	name string
+	room *Room
+	out  chan string
+	done chan struct{}
+	conn io.ReadWriteCloser
+	// Closes the connection once, whether the client is dropped or leaves.
+	closeConn sync.Once
+
+	// The player's state in the current race, guarded by the room.
+	game     *logic.Game
+	finished bool
+	elapsed  time.Duration
+}
+
+// Creates a client writing to conn.
+func newClient(conn io.ReadWriteCloser) *client {
+
+	c := &client{
+		out:  make(chan string, clientQueue),
+		done: make(chan struct{}),
+		conn: conn,
+	}
+
+	go func() {
+
+		defer close(c.done)
+
+		var err error
+
+		// Keep draining after a failed write so senders never block
+		for line := range c.out {
+
+			if err == nil {
+				_, err = fmt.Fprintln(conn, line)
+			}
+		}
+	}()
+
+	return c
+}
+
+// Queues a line to be written to the client without ever blocking. If
+// the queue is full the client isn't keeping up, so its connection is
+// closed, which ends its session and takes it out of its room.
+func (c *client) send(line string) {
+
+	select {
+	case c.out <- line:
+	default:
+		c.disconnect()
+	}
+}
+
+// Closes the connection, unblocking any write to it.
+func (c *client) disconnect() {
+
+	c.closeConn.Do(func() { c.conn.Close() })
+}
+
+// Flushes any queued lines and closes the connection.
+// The client must have left its room first.
+func (c *client) close() {
+
+	close(c.out)
+	<-c.done
+	c.disconnect()
+}
+
+// A group of players racing to solve the same answer.
+type Room struct {
+	Name string
+
+	mu      sync.Mutex
+	clients []*client
+	answer  string
+	racing  bool
+	start   time.Time
+}
+
+// Creates an empty room.
+func newRoom(name string) *Room {
+
+	return &Room{Name: name}
+}
+
+// Adds a client to the room under the given name.
+func (r *Room) join(c *client, name string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.racing {
+		return fmt.Errorf("a race is in progress")
+	}
+
+	for _, other := range r.clients {
+
+		if strings.EqualFold(other.name, name) {
+			return fmt.Errorf("name %s is taken", name)
+		}
+	}
+
+	c.name = name
+	r.clients = append(r.clients, c)
+	r.broadcast("JOINED " + name)
+
+	return nil
+}
+
+// Removes a client from the room and returns the number left.
+func (r *Room) leave(c *client) int {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, other := range r.clients {
+
+		if other == c {
+
+			r.clients = append(r.clients[:i], r.clients[i+1:]...)
+			break
+		}
+	}
+
+	r.broadcast("LEFT " + c.name)
+
+	// The race may have been waiting on this player only
+	if r.racing {
+		r.finishIfDone()
+	}
+
+	return len(r.clients)
+}
+
+// Starts a race on the given answer for everyone in the room.
+func (r *Room) startRace(answer string, now time.Time) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.racing {
+		return fmt.Errorf("a race is in progress")
+	}
+
+	r.answer = answer
+	r.racing = true
+	r.start = now
+
+	for _, c := range r.clients {
+
+		c.game = logic.CreateGame(answer)
+		c.finished = false
+		c.elapsed = 0
+	}
+
+	r.broadcast(fmt.Sprintf("STARTED %v", logic.MaxGuesses))
+
+	return nil
+}
+
+// Makes a guess for the client in the current race.
+func (r *Room) guess(c *client, word string, now time.Time) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.racing {
+		return fmt.Errorf("no race in progress")
+	}
+
+	if c.finished {
+		return fmt.Errorf("already finished")
+	}
+
+	if err := c.game.MakeGuess(word); err != nil {
+		return err
+	}
+
+	guesses := len(c.game.Guesses)
+	pattern := logic.FormatPattern(c.game.Results[guesses-1])
+
+	// Only the guesser gets to see the letters
+	c.send(fmt.Sprintf("RESULT %s %s", strings.ToLower(word), pattern))
+	r.broadcast(fmt.Sprintf("PROGRESS %s %v %s", c.name, guesses, pattern))
+
+	if c.game.IsOver() {
+
+		c.finished = true
+		c.elapsed = now.Sub(r.start)
+		r.broadcast(fmt.Sprintf("FINISHED %s %s %s", c.name, score(c), logic.FormatDuration(c.elapsed)))
+		r.finishIfDone()
+	}
+
+	return nil
+}
+
+// Ends the race with the rankings once every player has finished.
+// The room must be locked.
+func (r *Room) finishIfDone() {
+
+	for _, c := range r.clients {
+
+		if !c.finished {
+			return
+		}
+	}
+
+	for i, c := range r.rankings() {
+
+		r.broadcast(fmt.Sprintf("RANK %v %s %s %s", i+1, c.name, score(c), logic.FormatDuration(c.elapsed)))
+	}
+
+	r.broadcast("ANSWER " + r.answer)
+	r.racing = false
+}
+
+// Returns the players ordered from first to last: solvers before
+// everyone else, then by fewest guesses, then by fastest time.
+// The room must be locked.
+func (r *Room) rankings() []*client {
+
+	ranked := make([]*client, len(r.clients))
+	copy(ranked, r.clients)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+
+		a, b := ranked[i], ranked[j]
+
+		if a.game.HasWon() != b.game.HasWon() {
+			return a.game.HasWon()
+		}
+
+		if len(a.game.Guesses) != len(b.game.Guesses) {
+			return len(a.game.Guesses) < len(b.game.Guesses)
+		}
+
+		return a.elapsed < b.elapsed
+	})
+
+	return ranked
+}
+
+// Sends a line to everyone in the room. The room must be locked.
+func (r *Room) broadcast(line string) {
+
+	for _, c := range r.clients {
+
+		c.send(line)
+	}
+}
+
+// Returns the client's number of guesses, or X if they didn't solve it.
+func score(c *client) string {
+
+	if !c.game.HasWon() {
+		return "X"
+	}
+
+	return fmt.Sprint(len(c.game.Guesses))
+}
@@ -0,0 +1,173 @@
+// Package server races players against each other to solve the same
+// answer over a simple line based protocol on TCP.
+//
+// Clients send one command per line:
+//
+//	JOIN <room> <name>   join or create a room
+//	START                start a race in the current room
+//	GUESS <word>         make a guess in the current race
+//	QUIT                 disconnect
+//
+// and the server replies with lines such as:
+//
+//	JOINED <name>                      a player joined the room
+//	LEFT <name>                        a player left the room
+//	STARTED <max guesses>              a race has started
+//	RESULT <word> <pattern>            the result of your own guess
+//	PROGRESS <name> <guess> <pattern>  a player's guess, colors only
+//	FINISHED <name> <guesses|X> <time> a player solved the word or ran out
+//	RANK <place> <name> <guesses|X> <time>
+//	ANSWER <word>                      the race is over
+//	ERROR <message>
+//
+// Patterns use one character per letter: 'g' for green,
+// 'y' for yellow and 'b' for gray.
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Hosts any number of rooms, each running its own races.
+type Server struct {
+	// The clock races are timed with.
+	Clock logic.Clock
+	// Chooses the answer for each race.
+	SelectAnswer func() string
+
+	mu    sync.Mutex
+	rooms map[string]*Room
+}
+
+// Creates a server that picks answers at random.
+func New() *Server {
+
+	return &Server{
+		Clock:        logic.SystemClock{},
+		SelectAnswer: logic.RandomAnswer,
+		rooms:        make(map[string]*Room),
+	}
+}
+
+// Accepts connections on the listener until it fails, serving each
+// one on its own goroutine. The listener's error is returned.
+func (s *Server) Serve(l net.Listener) error {
+
+	for {
+
+		conn, err := l.Accept()
+
+		if err != nil {
+			return err
+		}
+
+		go s.ServeConn(conn)
+	}
+}
+
+// Serves a single client until it quits or disconnects, then closes the connection.
+func (s *Server) ServeConn(conn io.ReadWriteCloser) {
+
+	c := newClient(conn)
+	defer c.close()
+	defer s.leave(c)
+
+	scanner := bufio.NewScanner(conn)
+
+	for scanner.Scan() {
+
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		var err error
+
+		switch strings.ToUpper(fields[0]) {
+
+		case "JOIN":
+			if len(fields) != 3 {
+				err = fmt.Errorf("usage: JOIN <room> <name>")
+			} else {
+				err = s.join(c, fields[1], fields[2])
+			}
+
+		case "START":
+			if c.room == nil {
+				err = fmt.Errorf("not in a room")
+			} else {
+				err = c.room.startRace(s.SelectAnswer(), s.Clock.Now())
+			}
+
+		case "GUESS":
+			if len(fields) != 2 {
+				err = fmt.Errorf("usage: GUESS <word>")
+			} else if c.room == nil {
+				err = fmt.Errorf("not in a room")
+			} else {
+				err = c.room.guess(c, fields[1], s.Clock.Now())
+			}
+
+		case "QUIT":
+			return
+
+		default:
+			err = fmt.Errorf("unknown command %s", fields[0])
+		}
+
+		if err != nil {
+			c.send("ERROR " + err.Error())
+		}
+	}
+}
+
+// Adds the client to the named room, creating the room if needed.
+func (s *Server) join(c *client, roomName string, name string) error {
+
+	if c.room != nil {
+		return fmt.Errorf("already in room %s", c.room.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.rooms[roomName]
+
+	if !ok {
+		room = newRoom(roomName)
+	}
+
+	if err := room.join(c, name); err != nil {
+		return err
+	}
+
+	s.rooms[roomName] = room
+	c.room = room
+
+	return nil
+}
+
+// Removes the client from its room, closing the room once it is empty.
+func (s *Server) leave(c *client) {
+
+	if c.room == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c.room.leave(c) == 0 {
+		delete(s.rooms, c.room.Name)
+	}
+
+	c.room = nil
+}
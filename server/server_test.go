@@ -0,0 +1,235 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// A clock that only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+
+	return f.now
+}
+
+// Timers never fire, since the clock doesn't move on its own.
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+
+	return nil
+}
+
+// An in-process client connected to the server through a pipe.
+type testClient struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// Connects a new client to the server.
+func connect(t *testing.T, s *Server) *testClient {
+
+	serverConn, clientConn := net.Pipe()
+	go s.ServeConn(serverConn)
+
+	return &testClient{t: t, conn: clientConn, reader: bufio.NewReader(clientConn)}
+}
+
+// Sends a command to the server.
+func (c *testClient) send(format string, args ...interface{}) {
+
+	c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+
+	if _, err := fmt.Fprintf(c.conn, format+"\n", args...); err != nil {
+		c.t.Fatalf("Sending %q failed: %v", format, err)
+	}
+}
+
+// Reads lines until one is exactly the expected line.
+func (c *testClient) expect(expected string) {
+
+	for {
+
+		c.conn.SetReadDeadline(time.Now().Add(time.Second))
+		line, err := c.reader.ReadString('\n')
+
+		if err != nil {
+			c.t.Fatalf("Waiting for %q failed: %v", expected, err)
+		}
+
+		if strings.TrimSpace(line) == expected {
+			return
+		}
+	}
+}
+
+// Creates a server with a fixed answer and a fake clock.
+func newTestServer(answer string) (*Server, *fakeClock) {
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	s := New()
+	s.Clock = clock
+	s.SelectAnswer = func() string { return answer }
+
+	return s, clock
+}
+
+func TestRace(t *testing.T) {
+
+	s, clock := newTestServer("piety")
+	alice := connect(t, s)
+	bob := connect(t, s)
+
+	alice.send("JOIN lunch alice")
+	alice.expect("JOINED alice")
+	bob.send("JOIN lunch bob")
+	bob.expect("JOINED bob")
+	alice.expect("JOINED bob")
+
+	bob.send("START")
+	alice.expect(fmt.Sprintf("STARTED %v", logic.MaxGuesses))
+	bob.expect(fmt.Sprintf("STARTED %v", logic.MaxGuesses))
+
+	// Only the guesser sees the letters
+	alice.send("GUESS crane")
+	alice.expect("RESULT crane bbbby")
+	alice.expect("PROGRESS alice 1 bbbby")
+	bob.expect("PROGRESS alice 1 bbbby")
+
+	// Bob solves it first
+	clock.now = clock.now.Add(10 * time.Second)
+	bob.send("GUESS piety")
+	bob.expect("RESULT piety ggggg")
+	alice.expect("FINISHED bob 1 0:10.0")
+
+	// Joining mid-race is refused
+	carol := connect(t, s)
+	carol.send("JOIN lunch carol")
+	carol.expect("ERROR a race is in progress")
+
+	clock.now = clock.now.Add(5 * time.Second)
+	alice.send("GUESS piety")
+	alice.expect("FINISHED alice 2 0:15.0")
+
+	for _, c := range []*testClient{alice, bob} {
+
+		c.expect("RANK 1 bob 1 0:10.0")
+		c.expect("RANK 2 alice 2 0:15.0")
+		c.expect("ANSWER piety")
+	}
+}
+
+func TestLeavingEndsRace(t *testing.T) {
+
+	s, _ := newTestServer("piety")
+	alice := connect(t, s)
+	bob := connect(t, s)
+
+	alice.send("JOIN lunch alice")
+	alice.expect("JOINED alice")
+	bob.send("JOIN lunch bob")
+	alice.expect("JOINED bob")
+	alice.send("START")
+	alice.expect(fmt.Sprintf("STARTED %v", logic.MaxGuesses))
+	alice.send("GUESS piety")
+	alice.expect("FINISHED alice 1 0:00.0")
+
+	// The race only waited on bob
+	bob.send("QUIT")
+	alice.expect("LEFT bob")
+	alice.expect("RANK 1 alice 1 0:00.0")
+	alice.expect("ANSWER piety")
+}
+
+func TestErrors(t *testing.T) {
+
+	s, _ := newTestServer("piety")
+	alice := connect(t, s)
+
+	alice.send("START")
+	alice.expect("ERROR not in a room")
+	alice.send("DANCE")
+	alice.expect("ERROR unknown command DANCE")
+	alice.send("JOIN lunch alice")
+	alice.expect("JOINED alice")
+	alice.send("GUESS piety")
+	alice.expect("ERROR no race in progress")
+	alice.send("START")
+	alice.send("GUESS aaaaa")
+	alice.expect("ERROR must be a valid word")
+
+	bob := connect(t, s)
+	bob.send("JOIN other ALICE")
+	bob.expect("JOINED ALICE")
+}
+
+func TestServeOverTCP(t *testing.T) {
+
+	s, _ := newTestServer("piety")
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+
+	defer listener.Close()
+	go s.Serve(listener)
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+
+	client := &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+	defer conn.Close()
+
+	client.send("JOIN lunch alice")
+	client.expect("JOINED alice")
+	client.send("START")
+	client.send("GUESS piety")
+	client.expect("ANSWER piety")
+}
+
+func TestSlowReaderIsDropped(t *testing.T) {
+
+	s, _ := newTestServer("piety")
+
+	// Never reads anything the server sends
+	slow := connect(t, s)
+	slow.send("JOIN lunch slow")
+
+	// Every visitor sends the slow client two lines, filling its queue
+	for i := 0; i < clientQueue; i++ {
+
+		visitor := connect(t, s)
+		visitor.send("JOIN lunch visitor%v", i)
+		visitor.expect(fmt.Sprintf("JOINED visitor%v", i))
+		visitor.send("QUIT")
+	}
+
+	// Whatever got through before it was dropped, the connection ends
+	slow.conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	for {
+
+		if _, err := slow.reader.ReadString('\n'); err != nil {
+
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				t.Fatalf("The slow client was never disconnected.")
+			}
+
+			return
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/Dannflower/godle/cli"
+	"github.com/Dannflower/godle/stats"
+
+	"github.com/fatih/color"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+)
+
+// The permission extension holding the hash of the key a player logged in with.
+const keyHashExtension = "godle-key-hash"
+
+// Serves a game to everyone who connects over SSH until the process is stopped.
+func sshCommand(args []string) error {
+
+	flags := flag.NewFlagSet("ssh", flag.ExitOnError)
+	addr := flags.String("addr", ":2222", "address to listen on")
+	hostKeyPath := flags.String("hostkey", "", "host key file, created if missing (default in the user config directory)")
+	flags.Parse(args)
+
+	configDir, err := configDir()
+
+	if err != nil {
+		return err
+	}
+
+	if *hostKeyPath == "" {
+		*hostKeyPath = filepath.Join(configDir, "ssh_host_ed25519_key")
+	}
+
+	hostKey, err := loadHostKey(*hostKeyPath)
+
+	if err != nil {
+		return err
+	}
+
+	config := &ssh.ServerConfig{
+		// Anyone may play, the key only tells players apart
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+
+			hash := sha256.Sum256(key.Marshal())
+
+			return &ssh.Permissions{
+				Extensions: map[string]string{keyHashExtension: hex.EncodeToString(hash[:])},
+			}, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", *addr)
+
+	if err != nil {
+		return err
+	}
+
+	// Sessions always talk to a terminal
+	color.NoColor = false
+
+	fmt.Printf("Serving games over SSH on %v\n", listener.Addr())
+
+	for {
+
+		conn, err := listener.Accept()
+
+		if err != nil {
+			return err
+		}
+
+		go serveSSHConn(conn, config, filepath.Join(configDir, "ssh"))
+	}
+}
+
+// Performs the handshake on a new connection and plays
+// a game on every session the client opens.
+func serveSSHConn(conn net.Conn, config *ssh.ServerConfig, playerDir string) {
+
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, config)
+
+	if err != nil {
+
+		conn.Close()
+		return
+	}
+
+	defer serverConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	// Each key has its own stats and history files
+	playerPath := filepath.Join(playerDir, serverConn.Permissions.Extensions[keyHashExtension])
+
+	for newChannel := range channels {
+
+		if newChannel.ChannelType() != "session" {
+
+			newChannel.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+
+		if err != nil {
+			continue
+		}
+
+		go serveSSHSession(channel, channelRequests, playerPath)
+	}
+}
+
+// The payload of a pty-req request.
+type ptyRequest struct {
+	Term    string
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+	Modes   string
+}
+
+// The payload of a window-change request.
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+// Plays a game on the session's terminal once the client asks for a shell.
+func serveSSHSession(channel ssh.Channel, requests <-chan *ssh.Request, playerPath string) {
+
+	terminal := term.NewTerminal(channel, "")
+	session := cli.New(&terminalReader{terminal: terminal}, terminal, cli.ANSIRenderer{})
+	session.StatsPath = playerPath + ".json"
+	session.HistoryPath = playerPath + ".jsonl"
+	session.Terminal = true
+	session.ReadHidden = func() (string, error) { return terminal.ReadPassword("") }
+	started := false
+
+	for request := range requests {
+
+		ok := false
+
+		switch request.Type {
+
+		case "pty-req":
+			var pty ptyRequest
+
+			if ssh.Unmarshal(request.Payload, &pty) == nil {
+
+				resize(session, terminal, int(pty.Columns), int(pty.Rows))
+				ok = true
+			}
+
+		case "window-change":
+			var window windowChangeRequest
+
+			if ssh.Unmarshal(request.Payload, &window) == nil {
+
+				resize(session, terminal, int(window.Columns), int(window.Rows))
+				ok = true
+			}
+
+		case "shell":
+			if !started {
+
+				started = true
+				ok = true
+
+				go func() {
+
+					defer channel.Close()
+
+					// The session ends without error when the player disconnects
+					status := uint32(0)
+
+					if session.Run() != nil {
+						status = 1
+					}
+
+					channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+				}()
+			}
+		}
+
+		if request.WantReply {
+			request.Reply(ok, nil)
+		}
+	}
+}
+
+// Updates the session and its terminal to a new window size.
+func resize(session *cli.Session, terminal *term.Terminal, columns int, rows int) {
+
+	if columns <= 0 {
+		return
+	}
+
+	terminal.SetSize(columns, rows)
+	session.SetWidth(columns)
+}
+
+// Reads the lines typed on an SSH terminal, which handles echo and line editing.
+type terminalReader struct {
+	terminal *term.Terminal
+	buffered []byte
+}
+
+func (r *terminalReader) Read(p []byte) (int, error) {
+
+	if len(r.buffered) == 0 {
+
+		line, err := r.terminal.ReadLine()
+
+		if err != nil {
+			return 0, err
+		}
+
+		r.buffered = []byte(line + "\n")
+	}
+
+	n := copy(p, r.buffered)
+	r.buffered = r.buffered[n:]
+
+	return n, nil
+}
+
+// Loads the SSH host key at path, generating a new one if there is none.
+func loadHostKey(path string) (ssh.Signer, error) {
+
+	data, err := os.ReadFile(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+
+		if err != nil {
+			return nil, err
+		}
+
+		block, err := ssh.MarshalPrivateKey(key, "godle host key")
+
+		if err != nil {
+			return nil, err
+		}
+
+		data = pem.EncodeToMemory(block)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return nil, err
+		}
+
+	} else if err != nil {
+
+		return nil, err
+	}
+
+	return ssh.ParsePrivateKey(data)
+}
+
+// Returns the directory godle keeps its files in.
+func configDir() (string, error) {
+
+	path, err := stats.DefaultPath()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Dir(path), nil
+}
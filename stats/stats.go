@@ -0,0 +1,154 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dannflower/godle/internal/pathlock"
+)
+
+// Totals kept across every game played.
+type Stats struct {
+	Played        int
+	Won           int
+	CurrentStreak int
+	MaxStreak     int
+	// The number of games won with each number of guesses.
+	Distribution map[int]int
+	// Totals over timed games only.
+	TimedGames int
+	TotalTime  time.Duration
+	BestTime   time.Duration
+	// Hints taken, and the number of games they were taken in.
+	Hints       int
+	HintedGames int
+}
+
+// Returns the path of the stats file in the user's config directory.
+func DefaultPath() (string, error) {
+
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "godle", "stats.json"), nil
+}
+
+// Loads the stats stored at path. If there is no file
+// at path yet, empty stats are returned.
+func Load(path string) (*Stats, error) {
+
+	s := &Stats{Distribution: make(map[int]int)}
+	data, err := os.ReadFile(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+
+	if s.Distribution == nil {
+		s.Distribution = make(map[int]int)
+	}
+
+	return s, nil
+}
+
+// Saves the stats to path, creating its directory if needed.
+func (s *Stats) Save(path string) error {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// Loads the stats stored at path, changes them with the update and saves
+// them again. Nothing else in the process loads or saves the same file
+// through Update in the meantime, so no update is lost.
+func Update(path string, update func(*Stats)) error {
+
+	unlock := pathlock.Lock(path)
+	defer unlock()
+
+	s, err := Load(path)
+
+	if err != nil {
+		return err
+	}
+
+	update(s)
+
+	return s.Save(path)
+}
+
+// Records the outcome of a finished game. Elapsed is
+// left at zero for games that were not timed.
+func (s *Stats) Record(won bool, guesses int, elapsed time.Duration) {
+
+	s.Played++
+
+	if won {
+
+		s.Won++
+		s.CurrentStreak++
+		s.Distribution[guesses]++
+
+		if s.CurrentStreak > s.MaxStreak {
+			s.MaxStreak = s.CurrentStreak
+		}
+
+	} else {
+
+		s.CurrentStreak = 0
+	}
+
+	if elapsed > 0 {
+
+		s.TimedGames++
+		s.TotalTime += elapsed
+
+		if won && (s.BestTime == 0 || elapsed < s.BestTime) {
+			s.BestTime = elapsed
+		}
+	}
+}
+
+// Records the hints taken in a finished game.
+func (s *Stats) RecordHints(hints int) {
+
+	if hints > 0 {
+
+		s.Hints += hints
+		s.HintedGames++
+	}
+}
+
+// Returns the mean time of timed games, or zero if there were none.
+func (s *Stats) AverageTime() time.Duration {
+
+	if s.TimedGames == 0 {
+		return 0
+	}
+
+	return s.TotalTime / time.Duration(s.TimedGames)
+}
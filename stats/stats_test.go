@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecord(t *testing.T) {
+
+	s := &Stats{Distribution: make(map[int]int)}
+
+	s.Record(true, 3, 0)
+	s.Record(true, 4, 20*time.Second)
+	s.Record(false, 6, 10*time.Second)
+	s.Record(true, 3, 30*time.Second)
+
+	if s.Played != 4 || s.Won != 3 {
+		t.Fatalf("Record counted %v played and %v won, expected 4 and 3.", s.Played, s.Won)
+	}
+
+	if s.CurrentStreak != 1 || s.MaxStreak != 2 {
+		t.Fatalf("Record set streaks to %v/%v, expected 1/2.", s.CurrentStreak, s.MaxStreak)
+	}
+
+	if s.Distribution[3] != 2 || s.Distribution[4] != 1 || s.Distribution[6] != 0 {
+		t.Fatalf("Record produced distribution %v.", s.Distribution)
+	}
+
+	if s.TimedGames != 3 || s.BestTime != 20*time.Second || s.AverageTime() != 20*time.Second {
+		t.Fatalf("Record produced %v timed games, best %v, average %v.", s.TimedGames, s.BestTime, s.AverageTime())
+	}
+}
+
+func TestRecordHints(t *testing.T) {
+
+	s := &Stats{Distribution: make(map[int]int)}
+
+	s.RecordHints(2)
+	s.RecordHints(0)
+	s.RecordHints(1)
+
+	if s.Hints != 3 || s.HintedGames != 2 {
+		t.Fatalf("RecordHints counted %v hints in %v games, expected 3 in 2.", s.Hints, s.HintedGames)
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "godle", "stats.json")
+
+	// Missing file
+	s, err := Load(path)
+
+	if err != nil {
+		t.Fatalf("Load(%s) returned an error for a missing file: %v", path, err)
+	}
+
+	if s.Played != 0 || s.Distribution == nil {
+		t.Fatalf("Load(%s) did not return empty stats: %v", path, s)
+	}
+
+	s.Record(true, 2, 5*time.Second)
+
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save(%s) returned an error: %v", path, err)
+	}
+
+	loaded, err := Load(path)
+
+	if err != nil {
+		t.Fatalf("Load(%s) returned an error: %v", path, err)
+	}
+
+	if loaded.Played != 1 || loaded.Distribution[2] != 1 || loaded.BestTime != 5*time.Second {
+		t.Fatalf("Load(%s) returned %v, expected %v.", path, loaded, s)
+	}
+}
+
+func TestUpdateConcurrent(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "stats.json")
+	var wg sync.WaitGroup
+
+	// Like two SSH sessions finishing games with the same key at once
+	for i := 0; i < 50; i++ {
+
+		wg.Add(1)
+
+		go func() {
+
+			defer wg.Done()
+
+			if err := Update(path, func(s *Stats) { s.Record(true, 3, 0) }); err != nil {
+				t.Errorf("Update(%s) returned an error: %v", path, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if s, err := Load(path); err != nil || s.Played != 50 || s.Distribution[3] != 50 {
+		t.Fatalf("Load(%s) returned %v, %v after 50 updates at once.", path, s, err)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/Dannflower/godle/logic"
+)
+
+// Builds the decision tree for an opener and writes it out.
+func treeCommand(args []string) error {
+
+	flags := flag.NewFlagSet("tree", flag.ExitOnError)
+	strategy := flags.String("strategy", "entropy", "strategy shortlisting the guesses after the opener: "+strings.Join(logic.StrategyNames(), ", "))
+	shortlist := flags.Int("shortlist", 10, "guesses to search at each node, or 0 to play the strategy's picks without searching")
+	format := flags.String("format", "text", "output format: text, which assist -tree can load, or json")
+	output := flags.String("o", "", "file to write the tree to (default standard output)")
+	timeout := flags.Duration("timeout", 0, "give up if the tree isn't built in this long, or 0 to never give up")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: godle tree [options] opener")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expected one opener, got %v", flags.NArg())
+	}
+
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	// Stop building on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *timeout > 0 {
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	tree, err := logic.BuildDecisionTree(ctx, flags.Arg(0), *strategy, *shortlist)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Expected guesses: %.3f (%.3f with the %s strategy alone)\nMost guesses: %v\n", tree.ExpectedDepth, tree.GreedyExpectedDepth, tree.Strategy, tree.MaxDepth)
+
+	if *output == "" {
+		return writeTree(os.Stdout, tree, *format)
+	}
+
+	f, err := os.Create(*output)
+
+	if err != nil {
+		return err
+	}
+
+	err = writeTree(f, tree, *format)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Writes the tree in the named format, "text" or "json".
+func writeTree(w io.Writer, tree *logic.DecisionTree, format string) error {
+
+	if format == "json" {
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(tree)
+	}
+
+	return tree.WriteText(w)
+}